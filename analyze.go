@@ -0,0 +1,224 @@
+package routeimporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+const DEFAULT_ANALYZE_TOP = 10
+
+// AsCount specifies count of routes originated by an AS
+type AsCount struct {
+	As     uint32 `json:"as"`
+	Routes int    `json:"routes"`
+}
+
+// NextHopCount specifies count of routes via a next hop
+type NextHopCount struct {
+	NextHop string `json:"next_hop"`
+	Routes  int    `json:"routes"`
+}
+
+// TableStats specifies the profile of a route table
+type TableStats struct {
+	Total             int            `json:"total"`               // Routes (paths)
+	Best              int            `json:"best"`                // Routes marked as best
+	Prefixes          int            `json:"prefixes"`            // Unique prefixes
+	Multipath         int            `json:"multipath"`           // Prefixes with more than one path
+	Ipv4PrefixLengths map[int]int    `json:"ipv4_prefix_lengths"` // IPv4 routes per prefix length
+	Ipv6PrefixLengths map[int]int    `json:"ipv6_prefix_lengths"` // IPv6 routes per prefix length
+	AsPathLengths     map[int]int    `json:"as_path_lengths"`     // Routes per AS path length, AS_SET counted as one
+	OriginAsCount     int            `json:"origin_as_count"`     // Unique origin ASes
+	TopOriginAs       []AsCount      `json:"top_origin_as"`       // Origin ASes with most routes
+	NextHops          []NextHopCount `json:"next_hops"`           // Routes per next hop, most used first
+	Origins           map[string]int `json:"origins"`             // Routes per origin code
+	Attributes        map[string]int `json:"attributes"`          // Routes per present attribute
+	CommunityCounts   map[int]int    `json:"community_counts"`    // Routes per number of communities
+}
+
+// Analyze profiles routes as returned by ParseRoutes of any importer, reporting the
+// top origin ASes with most routes
+func Analyze(routes *[]Route, top int) (*TableStats, error) {
+	if routes == nil {
+		return nil, fmt.Errorf("cannot analyze - no routes")
+	}
+	if top <= 0 {
+		top = DEFAULT_ANALYZE_TOP
+	}
+
+	stats := &TableStats{
+		Ipv4PrefixLengths: map[int]int{},
+		Ipv6PrefixLengths: map[int]int{},
+		AsPathLengths:     map[int]int{},
+		Origins:           map[string]int{},
+		Attributes:        map[string]int{},
+		CommunityCounts:   map[int]int{},
+	}
+	prefixes := map[string]int{}
+	origins := map[uint32]int{}
+	nextHops := map[string]int{}
+	expanded := expandRoutes(*routes)
+	for i := range expanded {
+		route := &expanded[i]
+		stats.Total++
+		if route.Best {
+			stats.Best++
+		}
+		prefixes[routePrefixKey(route)]++
+		if route.Network != nil {
+			if route.Network.To4() != nil {
+				stats.Ipv4PrefixLengths[route.PrefixLen]++
+			} else {
+				stats.Ipv6PrefixLengths[route.PrefixLen]++
+			}
+		}
+		stats.AsPathLengths[asPathLength(route.AsPath)]++
+		stats.CommunityCounts[len(route.Communities)]++
+		if as, _, ok := originAs(route); ok {
+			origins[as]++
+		}
+		nextHops[route.NextHop]++
+
+		switch route.Origin {
+		case gosnappi.BgpRouteAdvancedOrigin.IGP:
+			stats.Origins["igp"]++
+		case gosnappi.BgpRouteAdvancedOrigin.EGP:
+			stats.Origins["egp"]++
+		default:
+			stats.Origins["incomplete"]++
+		}
+		for name, present := range map[string]bool{
+			"med":           route.Metric != nil,
+			"local_pref":    route.LocalPref != nil,
+			"weight":        route.Weight != nil && *route.Weight != 0,
+			"as_path":       len(route.AsPath) > 0,
+			"as_trans":      hasAsTrans(route.AsPath),
+			"communities":   len(route.Communities) > 0,
+			"route_targets": len(route.RouteTargets) > 0,
+			"labels":        route.InLabel != nil || route.OutLabel != nil,
+			"path_id":       route.PathId != 0,
+			"evpn":          route.Evpn != nil,
+		} {
+			if present {
+				stats.Attributes[name]++
+			}
+		}
+	}
+
+	stats.Prefixes = len(prefixes)
+	for _, count := range prefixes {
+		if count > 1 {
+			stats.Multipath++
+		}
+	}
+
+	stats.OriginAsCount = len(origins)
+	for as, count := range origins {
+		stats.TopOriginAs = append(stats.TopOriginAs, AsCount{As: as, Routes: count})
+	}
+	sort.Slice(stats.TopOriginAs, func(i, j int) bool {
+		if stats.TopOriginAs[i].Routes != stats.TopOriginAs[j].Routes {
+			return stats.TopOriginAs[i].Routes > stats.TopOriginAs[j].Routes
+		}
+		return stats.TopOriginAs[i].As < stats.TopOriginAs[j].As
+	})
+	if len(stats.TopOriginAs) > top {
+		stats.TopOriginAs = stats.TopOriginAs[:top]
+	}
+	for nextHop, count := range nextHops {
+		stats.NextHops = append(stats.NextHops, NextHopCount{NextHop: nextHop, Routes: count})
+	}
+	sort.Slice(stats.NextHops, func(i, j int) bool {
+		if stats.NextHops[i].Routes != stats.NextHops[j].Routes {
+			return stats.NextHops[i].Routes > stats.NextHops[j].Routes
+		}
+		return stats.NextHops[i].NextHop < stats.NextHops[j].NextHop
+	})
+
+	return stats, nil
+}
+
+// Json returns the stats in JSON format
+func (stats *TableStats) Json() ([]byte, error) {
+	return json.MarshalIndent(stats, "", "  ")
+}
+
+// String returns the stats as human readable tables
+func (stats *TableStats) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Routes: %d, Best: %d, Prefixes: %d, Multipath prefixes: %d\n",
+		stats.Total, stats.Best, stats.Prefixes, stats.Multipath))
+	writeHistogram(&b, "IPv4 prefix length", stats.Ipv4PrefixLengths)
+	writeHistogram(&b, "IPv6 prefix length", stats.Ipv6PrefixLengths)
+	writeHistogram(&b, "AS path length", stats.AsPathLengths)
+	writeHistogram(&b, "Communities", stats.CommunityCounts)
+
+	if len(stats.TopOriginAs) > 0 {
+		b.WriteString(fmt.Sprintf("\nOrigin ASes: %d\n", stats.OriginAsCount))
+		b.WriteString(fmt.Sprintf("%-20s %8s\n", "Origin AS", "Routes"))
+		for _, as := range stats.TopOriginAs {
+			b.WriteString(fmt.Sprintf("%-20d %8d\n", as.As, as.Routes))
+		}
+	}
+	if len(stats.NextHops) > 0 {
+		b.WriteString(fmt.Sprintf("\n%-40s %8s\n", "Next hop", "Routes"))
+		for _, nh := range stats.NextHops {
+			b.WriteString(fmt.Sprintf("%-40s %8d\n", nh.NextHop, nh.Routes))
+		}
+	}
+	writeCounts(&b, "Origin", stats.Origins)
+	writeCounts(&b, "Attribute", stats.Attributes)
+
+	return b.String()
+}
+
+func writeHistogram(b *strings.Builder, title string, histogram map[int]int) {
+	if len(histogram) == 0 {
+		return
+	}
+	keys := []int{}
+	for key := range histogram {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	b.WriteString(fmt.Sprintf("\n%-20s %8s\n", title, "Routes"))
+	for _, key := range keys {
+		b.WriteString(fmt.Sprintf("%-20d %8d\n", key, histogram[key]))
+	}
+}
+
+func writeCounts(b *strings.Builder, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := []string{}
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	b.WriteString(fmt.Sprintf("\n%-20s %8s\n", title, "Routes"))
+	for _, key := range keys {
+		b.WriteString(fmt.Sprintf("%-20s %8d\n", key, counts[key]))
+	}
+}
+
+// asPathLength returns AS path length as used in best path selection, AS_SET counted
+// as one and confederation segments not counted
+func asPathLength(segments []AsPathSegment) int {
+	length := 0
+	for _, seg := range segments {
+		switch seg.Type {
+		case gosnappi.BgpAsPathSegmentType.AS_SET:
+			length++
+		case gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ, gosnappi.BgpAsPathSegmentType.AS_CONFED_SET:
+		default:
+			length += len(seg.AsNumbers)
+		}
+	}
+
+	return length
+}
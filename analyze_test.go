@@ -0,0 +1,72 @@
+package routeimporter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+)
+
+func TestAnalyzeRoutes(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "stats"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+
+	stats, err := routeimporter.Analyze(routes, 1)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not analyze routes. error: %v", err))
+		return
+	}
+	fmt.Print(stats.String())
+
+	if stats.Total != 6 || stats.Best != 3 || stats.Prefixes != 2 || stats.Multipath != 2 {
+		t.Errorf("Unexpected route counts %+v", stats)
+	}
+	if !reflect.DeepEqual(stats.Ipv4PrefixLengths, map[int]int{24: 6}) || len(stats.Ipv6PrefixLengths) != 0 {
+		t.Errorf("Unexpected prefix lengths v4 %v v6 %v", stats.Ipv4PrefixLengths, stats.Ipv6PrefixLengths)
+	}
+	if !reflect.DeepEqual(stats.AsPathLengths, map[int]int{1: 3, 4: 3}) {
+		t.Errorf("Unexpected AS path lengths %v", stats.AsPathLengths)
+	}
+	if !reflect.DeepEqual(stats.TopOriginAs, []routeimporter.AsCount{{As: 15169, Routes: 3}}) {
+		t.Errorf("Unexpected top origin AS %v", stats.TopOriginAs)
+	}
+	expNextHops := []routeimporter.NextHopCount{
+		{NextHop: "67.16.148.37", Routes: 4}, {NextHop: "67.16.148.38", Routes: 1}, {NextHop: "67.16.148.40", Routes: 1},
+	}
+	if !reflect.DeepEqual(stats.NextHops, expNextHops) {
+		t.Errorf("Unexpected next hops %v", stats.NextHops)
+	}
+	if !reflect.DeepEqual(stats.Origins, map[string]int{"igp": 4, "egp": 1, "incomplete": 1}) {
+		t.Errorf("Unexpected origins %v", stats.Origins)
+	}
+	if !reflect.DeepEqual(stats.Attributes, map[string]int{"med": 6, "local_pref": 6, "as_path": 6}) {
+		t.Errorf("Unexpected attributes %v", stats.Attributes)
+	}
+
+	js, err := stats.Json()
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not convert stats to JSON. error: %v", err))
+		return
+	}
+	decoded := routeimporter.TableStats{}
+	if err := json.Unmarshal(js, &decoded); err != nil || !reflect.DeepEqual(decoded, *stats) {
+		t.Errorf("JSON stats do not match, error: %v", err)
+	}
+}
@@ -1,6 +1,11 @@
 package routeimporter
 
-import "github.com/open-traffic-generator/snappi/gosnappi"
+import (
+	"net"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
 
 // route import common structure
 
@@ -12,6 +17,32 @@ const (
 	ImportFileTypeCisco ImportFileType = iota
 	// ImportFileTypeJuniper - file in Cisco Route Format
 	ImportFileTypeJuniper
+	// ImportFileTypeEvpn - file in Cisco / Arista / FRR show bgp l2vpn evpn format
+	ImportFileTypeEvpn
+	// ImportFileTypeCiscoRib - file in Cisco show ip route / show ipv6 route format
+	ImportFileTypeCiscoRib
+	// ImportFileTypeMrtUpdates - MRT BGP4MP / BGP4MP_ET update stream (RFC 6396)
+	ImportFileTypeMrtUpdates
+	// ImportFileTypeCsv - CSV / TSV route list, see CsvConfig
+	ImportFileTypeCsv
+	// ImportFileTypeRouteList - YAML / JSON route list, see RouteList
+	ImportFileTypeRouteList
+	// ImportFileTypeBird - file in BIRD show route all format
+	ImportFileTypeBird
+	// ImportFileTypeGobgp - file in gobgp global rib -j / gobgp neighbor <ip> adj-in -j JSON format
+	ImportFileTypeGobgp
+	// ImportFileTypeExabgp - ExaBGP configuration / API route statements
+	ImportFileTypeExabgp
+)
+
+// ExportFileType specifies format of the file being exported
+type ExportFileType int
+
+const (
+	// ExportFileTypeCisco - file in Cisco Route Format
+	ExportFileTypeCisco ExportFileType = iota
+	// ExportFileTypeMrt - MRT TABLE_DUMP_V2 (RFC 6396) binary dump
+	ExportFileTypeMrt
 )
 
 // RouteType specifies imported route type
@@ -26,18 +57,291 @@ const (
 	RouteTypeIpv6
 )
 
+// AsNumberWidth specifies AS number encoding of the target peers
+type AsNumberWidth int
+
+const (
+	// AsNumberWidthAuto - AS number width of target peers is left unchanged
+	AsNumberWidthAuto AsNumberWidth = iota
+	// AsNumberWidthTwo - 2 byte AS numbers, ASNs above 65535 replaced by AS_TRANS (23456) in AS paths
+	AsNumberWidthTwo
+	// AsNumberWidthFour - 4 byte AS numbers
+	AsNumberWidthFour
+)
+
+// MergeMode specifies how imported routes are merged into existing route ranges of the target peer
+type MergeMode int
+
+const (
+	// MergeModeAppend - append imported routes to existing route ranges
+	MergeModeAppend MergeMode = iota
+	// MergeModeReplace - remove existing route ranges before import
+	MergeModeReplace
+	// MergeModeUpsert - update existing route range of same prefix, append other routes
+	MergeModeUpsert
+	// MergeModeSkip - keep existing route range of same prefix, append other routes
+	MergeModeSkip
+)
+
+// RouteGroupMode specifies how route groups are created for imported routes on the target peer
+type RouteGroupMode int
+
+const (
+	// RouteGroupNone - no route groups are created
+	RouteGroupNone RouteGroupMode = iota
+	// RouteGroupAll - one route group with all imported routes
+	RouteGroupAll
+	// RouteGroupOriginAs - one route group per origin AS, last AS of the AS path
+	RouteGroupOriginAs
+	// RouteGroupPrefixLen - one route group per prefix length
+	RouteGroupPrefixLen
+	// RouteGroupNextHop - one route group per next hop, as found in import file
+	RouteGroupNextHop
+	// RouteGroupChunk - route groups of RouteGroupSize routes each, in import order
+	RouteGroupChunk
+	// RouteGroupRpki - one route group per RPKI origin validation state
+	RouteGroupRpki
+	// RouteGroupWeight - one route group per weight, routes without weight in weight 0 group
+	RouteGroupWeight
+)
+
+// SampleMode specifies how a subset of parsed routes is selected for import
+type SampleMode int
+
+const (
+	// SampleNone - all routes are imported
+	SampleNone SampleMode = iota
+	// SampleEveryNth - every Nth route in import order, N chosen to import SampleSize routes
+	SampleEveryNth
+	// SampleRandom - SampleSize random routes, same SampleSeed selects same routes
+	SampleRandom
+	// SamplePrefixLen - random routes, stratified by prefix length
+	SamplePrefixLen
+	// SampleOriginAs - random routes, stratified by origin AS
+	SampleOriginAs
+	// SampleFirst - first SampleSize routes in import order
+	SampleFirst
+)
+
+// RpkiState specifies RPKI origin validation state of a route (RFC 6811)
+type RpkiState int
+
+const (
+	// RpkiStateUnknown - route not validated
+	RpkiStateUnknown RpkiState = iota
+	// RpkiStateValid - origin AS and prefix length match a covering ROA
+	RpkiStateValid
+	// RpkiStateInvalid - covering ROAs found, none matching origin AS and prefix length
+	RpkiStateInvalid
+	// RpkiStateNotFound - no covering ROA found
+	RpkiStateNotFound
+)
+
+// Roa specifies a validated ROA payload
+type Roa struct {
+	Prefix    net.IPNet // ROA prefix
+	MaxLength int       // Maximum prefix length
+	Asn       uint32    // Authorized origin AS
+}
+
+// BogonFilter specifies prefixes and AS numbers of routes removed from the import
+type BogonFilter struct {
+	Prefixes     []string // Bogon prefixes, routes within a prefix are removed
+	Asns         []string // Bogon AS numbers or ranges (e.g. "64512-65534"), routes with any of them in AS path are removed
+	DefaultRoute bool     // Remove default routes 0.0.0.0/0 and ::/0
+}
+
+// ScaleConfig specifies copies of imported routes in additional address space
+type ScaleConfig struct {
+	Copies         int    // Copies added per route, e.g. 4 copies turn a table of 1M routes into 5M routes
+	Ipv4Offset     string // Address added to IPv4 networks per copy, block spanning the IPv4 table if empty
+	Ipv6Offset     string // Address added to IPv6 networks per copy, block spanning the IPv6 table if empty
+	OriginAsOffset uint32 // Added to origin AS per copy, AS paths are kept if 0
+	VaryNextHop    bool   // Next hop incremented by copy number
+}
+
+// CsvConfig specifies the layout of CSV / TSV route lists
+type CsvConfig struct {
+	Delimiter rune           // Field delimiter, detected from the first line (tab, semicolon or comma) if 0
+	Columns   map[string]int // Column index per field (CSV_FIELD_*), from the header line or CSV_DEFAULT_COLUMNS if nil
+}
+
+// RouteList specifies routes of the YAML / JSON route list format
+type RouteList struct {
+	Defaults RouteListEntry   `json:"defaults,omitempty"` // Attributes of routes not setting them
+	Routes   []RouteListEntry `json:"routes"`             // Routes in import order
+}
+
+// RouteListEntry specifies a route, or a route range of consecutive prefixes, of a route list
+type RouteListEntry struct {
+	Prefix      string   `json:"prefix"`                // Network/length
+	Count       int      `json:"count,omitempty"`       // Consecutive prefixes of the prefix length, 1 if not set
+	NextHop     string   `json:"next_hop,omitempty"`    // Next hop address
+	AsPath      string   `json:"as_path,omitempty"`     // AS path without origin, e.g. "6939 {7545,56203}"
+	Origin      string   `json:"origin,omitempty"`      // igp, egp, incomplete or i, e, ?
+	Med         *uint32  `json:"med,omitempty"`         // Multi exit discriminator
+	LocalPref   *uint32  `json:"local_pref,omitempty"`  // Local preference
+	Communities []string `json:"communities,omitempty"` // Standard communities, e.g. 65000:100, no-export
+	PathId      uint32   `json:"path_id,omitempty"`     // Add-path path identifier
+	Peer        string   `json:"peer,omitempty"`        // Target peer name, target peers of the import config if empty
+}
+
+// FilteredRoute specifies a route removed from the import
+type FilteredRoute struct {
+	Route  Route  // Removed route
+	Reason string // Reason of removal, e.g. "bogon prefix 10.0.0.0/8"
+}
+
+// ImportReport specifies how imported routes are merged into the target peer
+type ImportReport struct {
+	Added     int // Route ranges added
+	Updated   int // Existing route ranges updated with changed attributes
+	Unchanged int // Existing route ranges left unchanged
+	Removed   int // Existing route ranges removed by MergeModeReplace
+	Renamed   int // Added route ranges renamed as their name was already used
+
+	Filtered []FilteredRoute // Routes removed by bogon filter or RPKI origin validation
+	AsTrans  int             // Routes with AS_TRANS (23456) in AS path, sent by 2 byte AS speakers
+
+	ScaleSkipped int // Scale copies skipped, their prefix already in the table or another copy
+}
+
 // Import configuration specified parameters to control import behavior
 type ImportConfig struct {
-	NamePrefix        string               // Route name prefix
-	RRType            RouteType            // detect route address type
-	BestRoutes        bool                 // import best routes only
-	RetainNexthop     bool                 // retain next hop
-	SequentialProcess bool                 // Process in sequence
-	Targetv4Peers     []gosnappi.BgpV4Peer // Target v4 peer that is updated with valid v4 routes
-	Targetv6Peers     []gosnappi.BgpV6Peer // Target v6 peer that is updated with valid v6 routes
+	NamePrefix        string                      // Route name prefix
+	NameTemplate      string                      // Route name template, e.g. "{prefix}-{network}-{len}"
+	RRType            RouteType                   // detect route address type
+	BestRoutes        bool                        // import best routes only
+	RetainNexthop     bool                        // retain next hop
+	SequentialProcess bool                        // Process in sequence
+	SplitInternal     bool                        // internal routes imported to the iBGP target peer, external routes to the eBGP target peer
+	AsNumberWidth     AsNumberWidth               // AS number encoding set on the target peers
+	Targetv4Peers     []gosnappi.BgpV4Peer        // Target v4 peer that is updated with valid v4 routes
+	Targetv6Peers     []gosnappi.BgpV6Peer        // Target v6 peer that is updated with valid v6 routes
+	Vrfs              []string                    // import routes of listed VRF names / route distinguishers only
+	VrfRouteTargets   map[string][]string         // route targets per VRF, route distinguisher is used if not listed
+	TargetIsisRouters []gosnappi.DeviceIsisRouter // Target IS-IS router that is updated with RIB routes
+	RibProtocols      []string                    // RIB protocol codes to import (e.g. "i L2", "O IA", "S"), all if empty
+	MergeMode         MergeMode                   // merge with existing route ranges of target peer
+	Report            *ImportReport               // filled with merge counts of the import, if set
+	RouteGroups       RouteGroupMode              // route groups created for imported routes
+	RouteGroupSize    int                         // routes per route group of RouteGroupChunk
+	Roas              []Roa                       // ROAs for RPKI origin validation, routes are not validated if empty
+	RpkiStates        []RpkiState                 // RPKI origin validation states to import, all if empty
+	RpkiLocalAs       uint32                      // origin AS of routes without AS path for RPKI validation, AS of the target peer if 0
+	Bogons            *BogonFilter                // bogon routes are removed if set, see DefaultBogonFilter
+	Scale             *ScaleConfig                // routes are replicated into additional address space if set
+	Csv               *CsvConfig                  // layout of CSV route lists, detected if nil
+	WeightLocalPref   bool                        // weight differences between paths of a prefix raise local pref
+	Sample            SampleMode                  // subset of routes imported after filtering
+	SampleSize        int                         // routes imported by Sample mode
+	SampleSeed        int64                       // random seed of Sample mode
+}
+
+// AsPathSegment specifies one segment of a parsed AS path
+type AsPathSegment struct {
+	Type      gosnappi.BgpAsPathSegmentTypeEnum
+	AsNumbers []uint32
+}
+
+// EvpnRoute specifies EVPN NLRI fields of a parsed route
+type EvpnRoute struct {
+	RouteType   int      // EVPN route type, 2 (MAC/IP), 3 (IMET) or 5 (IP prefix)
+	Esi         string   // Ethernet segment identifier, 10 octets in hex
+	EthernetTag uint32   // Ethernet tag
+	Mac         string   // MAC address of type 2 routes
+	Labels      []uint32 // Labels / VNIs
+}
+
+// Route specifies a parsed route along with its attributes
+type Route struct {
+	Name         string                              // Route range name
+	Row          int                                 // Row in import file where route is found
+	Network      net.IP                              // Network address
+	PrefixLen    int                                 // Network prefix length
+	NextHop      string                              // Next hop, as found in import file
+	Metric       *uint32                             // MED, nil if not present
+	LocalPref    *uint32                             // Local preference, nil if not present
+	Weight       *uint32                             // Cisco weight, local to the router, nil if not present
+	Origin       gosnappi.BgpRouteAdvancedOriginEnum // Origin
+	AsPath       []AsPathSegment                     // AS path segments
+	Best         bool                                // Marked as best route
+	Internal     bool                                // Learned from an internal (iBGP) peer
+	Rd           string                              // Route distinguisher, empty for global table routes
+	Vrf          string                              // VRF name, empty for global table routes
+	RouteTargets []string                            // Route targets advertised as extended communities
+	Communities  []string                            // Standard communities (e.g. 65000:100, no-export)
+	Evpn         *EvpnRoute                          // EVPN NLRI fields, nil for non EVPN routes
+	InLabel      *uint32                             // Locally allocated MPLS label, nil if not present
+	OutLabel     *uint32                             // Received MPLS label, nil if not present
+	Protocol     string                              // RIB protocol code (e.g. "O IA", "i L2"), empty for BGP tables
+	Distance     *uint32                             // RIB administrative distance, nil if not present
+	PathId       uint32                              // BGP add-path path identifier, 0 if not present
+	RpkiState    RpkiState                           // RPKI origin validation state, if validated
+	Copy         int                                 // Scale copy number, 0 for imported route
+	Peer         string                              // Target peer name, target peers of the import config if empty
+	Count        int                                 // Consecutive prefixes of the route range, 1 if 0, expanded where checked per prefix
+}
+
+// RouteEventType specifies type of a route update event
+type RouteEventType int
+
+const (
+	// RouteEventAnnounce - route is announced, or announced again with changed attributes
+	RouteEventAnnounce RouteEventType = iota
+	// RouteEventWithdraw - route is withdrawn
+	RouteEventWithdraw
+)
+
+// RouteEvent specifies an announce / withdraw of a route found in an update stream
+type RouteEvent struct {
+	Offset      time.Duration  // Time since first update of the stream
+	Type        RouteEventType // Announce or withdraw
+	PeerAddress string         // Address of the peer sending the update
+	PeerAs      uint32         // AS number of the peer sending the update
+	Route       Route          // Route, only network, prefix length and path id are set for withdraws
+}
+
+// RouteAction specifies route state change of route ranges to be applied at an offset
+type RouteAction struct {
+	Offset time.Duration                        // Time since start of replay
+	State  gosnappi.StateProtocolRouteStateEnum // Advertise or withdraw
+	Names  []string                             // Route range names
+}
+
+// RouteChange specifies a route found in both tables with changed attributes
+type RouteChange struct {
+	Old     Route    // Route of the old table
+	New     Route    // Route of the new table
+	Changes []string // Names of changed attributes
+}
+
+// TableDiff specifies the delta between two route tables
+type TableDiff struct {
+	Added     []Route       // Routes only found in the new table
+	Removed   []Route       // Routes only found in the old table
+	Modified  []RouteChange // Routes found in both tables with changed attributes
+	Unchanged int           // Count of routes found in both tables with same attributes
 }
 
 type ImportService interface {
 	ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error)
 	String() string
 }
+
+// RouteParser is implemented by import services parsing routes without updating any target
+// peer, see ParseRoutes
+type RouteParser interface {
+	ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error)
+}
+
+// FormatDetector is implemented by import services recognizing their format in an import
+// buffer, see DetectImporterService
+type FormatDetector interface {
+	Detect(buffer *[]byte) bool
+}
+
+type ExportService interface {
+	ExportRoutes(routes *[]Route) (*[]byte, error)
+	String() string
+}
@@ -0,0 +1,63 @@
+package routeimporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AS_TRANS is the 2 byte AS number substituted for 4 byte AS numbers by 2 byte AS speakers (RFC 6793)
+const AS_TRANS = 23456
+
+// parseAsNumber parses an AS number in asplain (e.g. 4259840100) or asdot (e.g. 65000.100)
+// notation (RFC 5396)
+func parseAsNumber(as string) (uint32, error) {
+	if high, low, found := strings.Cut(as, "."); found {
+		h, err := strconv.ParseUint(high, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid AS number %q", as)
+		}
+		l, err := strconv.ParseUint(low, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid AS number %q", as)
+		}
+		return uint32(h)<<16 | uint32(l), nil
+	}
+	num, err := strconv.ParseUint(as, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AS number %q", as)
+	}
+
+	return uint32(num), nil
+}
+
+// hasAsTrans checks if AS_TRANS is found in the AS path
+func hasAsTrans(segments []AsPathSegment) bool {
+	for _, seg := range segments {
+		for _, as := range seg.AsNumbers {
+			if as == AS_TRANS {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// twoByteAsPath returns the AS path as sent by a 2 byte AS speaker, 4 byte AS numbers
+// replaced by AS_TRANS
+func twoByteAsPath(segments []AsPathSegment) []AsPathSegment {
+	path := make([]AsPathSegment, 0, len(segments))
+	for _, seg := range segments {
+		asNumbers := make([]uint32, 0, len(seg.AsNumbers))
+		for _, as := range seg.AsNumbers {
+			if as > 0xFFFF {
+				as = AS_TRANS
+			}
+			asNumbers = append(asNumbers, as)
+		}
+		path = append(path, AsPathSegment{Type: seg.Type, AsNumbers: asNumbers})
+	}
+
+	return path
+}
@@ -0,0 +1,109 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesAsNumbers(t *testing.T) {
+	filename := "resource/cisco_v4_asn.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	report := routeimporter.ImportReport{}
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "asn", Report: &report}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	expected := [][]routeimporter.AsPathSegment{
+		{{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{6939, 4259840100}}},
+		{
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{6939, 4259840100}},
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{4227858433, 64513}},
+		},
+		{{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{6939, 23456}}},
+		{
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{6939}},
+			{Type: gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ, AsNumbers: []uint32{4259905541}},
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{7545}},
+		},
+	}
+	if len(*routes) != len(expected) {
+		t.Errorf("Expected %d routes, found %d", len(expected), len(*routes))
+		return
+	}
+	for i, route := range *routes {
+		if !reflect.DeepEqual(route.AsPath, expected[i]) {
+			t.Errorf("Route %s: expected AS path %v, found %v", route.Name, expected[i], route.AsPath)
+		}
+	}
+	if report.AsTrans != 1 {
+		t.Errorf("Expected 1 route with AS_TRANS, found %d", report.AsTrans)
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65001)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "asn",
+		RRType:        routeimporter.RouteTypeIpv4,
+		AsNumberWidth: routeimporter.AsNumberWidthTwo,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if peer.AsNumberWidth() != gosnappi.BgpV4PeerAsNumberWidth.TWO {
+		t.Errorf("Expected 2 byte AS number width, found %s", peer.AsNumberWidth())
+	}
+	segments := peer.V4Routes().Items()[1].AsPath().Segments().Items()
+	if !reflect.DeepEqual(segments[0].AsNumbers(), []uint32{6939, 23456}) ||
+		!reflect.DeepEqual(segments[1].AsNumbers(), []uint32{23456, 64513}) {
+		t.Errorf("Expected 4 byte AS numbers replaced by AS_TRANS, found %v, %v",
+			segments[0].AsNumbers(), segments[1].AsNumbers())
+	}
+}
+
+func TestParseRoutesInvalidAsNumber(t *testing.T) {
+	filename := "resource/cisco_v4_asn.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	header := strings.Join(strings.SplitAfter(string(fb), "\n")[:7], "")
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	for _, path := range []string{"6939 4294967296 i", "6939 65536.1 i", "6939 65000. i"} {
+		fb := []byte(header + "*> 1.0.0.0/24       67.16.148.37            50    200      0 " + path + "\n")
+		routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "asn"}, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+			continue
+		}
+		if len(*routes) != 0 {
+			t.Errorf("Expected route with AS path %q skipped, found %d routes", path, len(*routes))
+		}
+	}
+}
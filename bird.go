@@ -0,0 +1,259 @@
+package routeimporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	BIRD_PRIMARY_ROUTE = "*"
+	BIRD_TYPE_PREFIX   = "Type:"
+	BIRD_TYPE_BGP      = "BGP"
+	BIRD_VIA           = "via"
+	BIRD_TABLE_PREFIX  = "Table "
+
+	BIRD_ATTR_PREFIX      = "BGP."
+	BIRD_ATTR_ORIGIN      = "BGP.origin:"
+	BIRD_ATTR_AS_PATH     = "BGP.as_path:"
+	BIRD_ATTR_NEXT_HOP    = "BGP.next_hop:"
+	BIRD_ATTR_MED         = "BGP.med:"
+	BIRD_ATTR_LOCAL_PREF  = "BGP.local_pref:"
+	BIRD_ATTR_COMMUNITIES = "BGP.community:"
+)
+
+// BirdImporter imports routes from BIRD show route all output
+type BirdImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+	lines       []string
+}
+
+// birdEntry holds a route of show route all output while its attribute lines are parsed
+type birdEntry struct {
+	Route Route
+	Bgp   bool
+	Err   error
+}
+
+// String returns the id of the client.
+func (imp *BirdImporter) String() string {
+	return fmt.Sprintf("BIRD Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for BGP attribute lines of show route all output
+func (imp *BirdImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		if strings.HasPrefix(strings.TrimSpace(line), BIRD_ATTR_AS_PATH) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportRoutes adds one route range per BGP route, IPv4 routes to the target v4 peer and
+// IPv6 routes to the target v6 peer.
+func (imp *BirdImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses BGP routes of BIRD 1.x / 2.x show route all output, each route line
+// followed by its attribute lines. Routes of other protocols are skipped, and routes
+// without primary route marker (*) if BestRoutes is set.
+func (imp *BirdImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	imp.lines = strings.Split(string(*buffer), "\n")
+	routes := []Route{}
+	var entry *birdEntry
+	prefix := ""
+	flush := func() {
+		if entry == nil {
+			return
+		}
+		route := entry.Route
+		switch {
+		case entry.Err != nil:
+			log.Info().Msgf(entry.Err.Error())
+		case !entry.Bgp:
+		case ic.BestRoutes && !route.Best:
+		case (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+			(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4):
+		default:
+			routes = append(routes, route)
+		}
+		entry = nil
+	}
+
+	for index, line := range imp.lines {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		indented := line[0] == SPACE_CHAR || line[0] == '\t'
+		if indented && (strings.HasPrefix(trimmed, BIRD_ATTR_PREFIX) || !strings.Contains(trimmed, "[")) {
+			if entry != nil && entry.Err == nil {
+				entry.Err = parseBirdAttribute(entry, trimmed, index)
+			}
+			continue
+		}
+
+		flush()
+		if strings.HasPrefix(line, BIRD_TABLE_PREFIX) || !strings.Contains(trimmed, "[") {
+			// table name, banner
+			prefix = ""
+			continue
+		}
+		if !indented {
+			fields := strings.Fields(trimmed)
+			prefix, trimmed = fields[0], strings.TrimSpace(strings.TrimPrefix(trimmed, fields[0]))
+		}
+		if prefix == "" {
+			continue
+		}
+		entry = &birdEntry{}
+		entry.Err = parseBirdRouteLine(entry, prefix, trimmed, index)
+	}
+	flush()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+
+	routes, err := processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// parseBirdRouteLine parses a route line like
+// "unicast [peer1 2023-01-01 from 192.0.2.1] * (100) [AS13335i]" (BIRD 2) or
+// "via 192.0.2.1 on eth0 [peer1 2019-01-01 from 192.0.2.1] * (100) [AS13335i]" (BIRD 1)
+// following the prefix of the route
+func parseBirdRouteLine(entry *birdEntry, prefix string, line string, row int) error {
+	ip, length, err := parsePrefix(prefix)
+	if err != nil {
+		return fmt.Errorf("%v (line %d)", err, row+1)
+	}
+	entry.Route = Route{Row: row, Network: ip, PrefixLen: length}
+
+	open, close := strings.Index(line, "["), strings.Index(line, "]")
+	if close < open {
+		return fmt.Errorf("incorrect format of route line (line %d)", row+1)
+	}
+	if fields := strings.Fields(line[:open]); len(fields) > 1 && fields[0] == BIRD_VIA {
+		entry.Route.NextHop = fields[1]
+	}
+	if fields := strings.Fields(line[close+1:]); len(fields) > 0 && fields[0] == BIRD_PRIMARY_ROUTE {
+		entry.Route.Best = true
+	}
+
+	return nil
+}
+
+// parseBirdAttribute parses an attribute line of a route
+func parseBirdAttribute(entry *birdEntry, line string, row int) error {
+	route := &entry.Route
+	fields := strings.Fields(line)
+	value := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+	var err error
+	switch {
+	case fields[0] == BIRD_TYPE_PREFIX:
+		entry.Bgp = len(fields) > 1 && fields[1] == BIRD_TYPE_BGP
+	case fields[0] == BIRD_VIA:
+		// BIRD 2 next hop line, BGP.next_hop takes precedence
+		if route.NextHop == "" && len(fields) > 1 {
+			route.NextHop = fields[1]
+		}
+	case !strings.HasPrefix(line, BIRD_ATTR_PREFIX):
+	case strings.HasPrefix(line, BIRD_ATTR_ORIGIN):
+		entry.Bgp = true
+		route.Origin, err = parseOriginName(value)
+	case strings.HasPrefix(line, BIRD_ATTR_AS_PATH):
+		entry.Bgp = true
+		route.AsPath, err = parseAsSegments(value, row)
+	case strings.HasPrefix(line, BIRD_ATTR_NEXT_HOP):
+		// IPv6 next hop may be followed by its link local address
+		if next := strings.Fields(value); len(next) > 0 {
+			route.NextHop = next[0]
+		}
+	case strings.HasPrefix(line, BIRD_ATTR_MED):
+		route.Metric, err = parseBirdValue(value)
+	case strings.HasPrefix(line, BIRD_ATTR_LOCAL_PREF):
+		route.LocalPref, err = parseBirdValue(value)
+	case strings.HasPrefix(line, BIRD_ATTR_COMMUNITIES):
+		route.Communities, err = parseBirdCommunities(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%v (line %d)", err, row+1)
+	}
+
+	return nil
+}
+
+// parseBirdValue parses a 32 bit attribute value
+func parseBirdValue(value string) (*uint32, error) {
+	num, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attribute value: %q", value)
+	}
+	v := uint32(num)
+
+	return &v, nil
+}
+
+// parseBirdCommunities parses communities like "(65000,100) (65535,65281)"
+func parseBirdCommunities(value string) ([]string, error) {
+	communities := []string{}
+	for _, token := range strings.Fields(value) {
+		parts := strings.Split(strings.Trim(token, "()"), ",")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid community: %q", token)
+		}
+		c, err := communityValue(parts[0] + ":" + parts[1])
+		if err != nil {
+			return nil, err
+		}
+		communities = append(communities, communityString(c))
+	}
+
+	return communities, nil
+}
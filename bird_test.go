@@ -0,0 +1,116 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestParseRoutesBird(t *testing.T) {
+	filename := "resource/bird_show_route_all.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeBird)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "bird"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	// static route skipped
+	names := []string{}
+	for _, route := range *routes {
+		names = append(names, route.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"bird-3", "bird-12", "bird-20", "bird-32", "bird-39"}) {
+		t.Errorf("Unexpected routes %v", names)
+		return
+	}
+
+	first, second, v6 := (*routes)[0], (*routes)[1], (*routes)[3]
+	if !first.Best || first.NextHop != "192.0.2.1" || *first.LocalPref != 100 || first.Metric != nil ||
+		first.Origin != gosnappi.BgpRouteAdvancedOrigin.IGP ||
+		!reflect.DeepEqual(first.AsPath[0].AsNumbers, []uint32{6939, 13335}) ||
+		!reflect.DeepEqual(first.Communities, []string{"65000:100", "no-export"}) {
+		t.Errorf("Unexpected first route %+v", first)
+	}
+	expectedPath := []routeimporter.AsPathSegment{
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{3356}},
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{13335, 65010}},
+	}
+	if second.Best || second.Network.String() != "1.0.0.0" || second.NextHop != "192.0.2.2" || *second.Metric != 50 ||
+		*second.LocalPref != 90 || !reflect.DeepEqual(second.AsPath, expectedPath) {
+		t.Errorf("Unexpected second route %+v", second)
+	}
+	if (*routes)[2].Origin != gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE {
+		t.Errorf("Expected incomplete origin, found %s", (*routes)[2].Origin)
+	}
+	if v6.Network.String() != "2001:db8:100::" || v6.PrefixLen != 48 || v6.NextHop != "2001:db8::1" ||
+		v6.AsPath[0].AsNumbers[1] != 4259840100 {
+		t.Errorf("Unexpected IPv6 route %+v", v6)
+	}
+
+	ic := routeimporter.ImportConfig{NamePrefix: "bird", BestRoutes: true, RRType: routeimporter.RouteTypeIpv4}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 2 || (*routes)[0].Name != "bird-3" || (*routes)[1].Name != "bird-20" {
+		t.Errorf("Expected 2 primary IPv4 routes, found %d", len(*routes))
+	}
+
+	if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "bird" {
+		t.Errorf("Expected bird format detected, found %q, error: %v", name, err)
+	}
+}
+
+func TestImportRoutesBird1(t *testing.T) {
+	filename := "resource/bird1_show_route_all.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeBird)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "bird",
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if !reflect.DeepEqual(*names, []string{"bird-2", "bird-9"}) {
+		t.Errorf("Unexpected imported routes %v", *names)
+		return
+	}
+	rrs := peer.V4Routes().Items()
+	if rrs[0].NextHopIpv4Address() != "192.0.2.1" || rrs[1].NextHopIpv4Address() != "192.0.2.2" ||
+		rrs[0].Communities().Items()[0].AsNumber() != 65000 {
+		t.Errorf("Unexpected route ranges %s, %s", rrs[0].NextHopIpv4Address(), rrs[1].NextHopIpv4Address())
+	}
+}
@@ -3,6 +3,7 @@ package routeimporter
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,23 +22,50 @@ const (
 	CISCO_HEADER_WEIGHT       = "Weight"
 	CISCO_HEADER_PATH         = "Path"
 
-	CISCO_VALID_ROUTE = '*'
-	CISCO_BEST_ROUTE  = '>'
+	CISCO_HEADER_IN_OUT_LABEL = "In label/Out label"
+	CISCO_HEADER_RCVD_LABEL   = "Rcvd Label"
+	CISCO_HEADER_LOCAL_LABEL  = "Local Label"
+
+	CISCO_NO_LABEL            = "nolabel"
+	CISCO_IMPLICIT_NULL_LABEL = "imp-null"
+	CISCO_EXPLICIT_NULL_LABEL = "exp-null"
+
+	MPLS_IMPLICIT_NULL_LABEL = 3
+	MPLS_EXPLICIT_NULL_LABEL = 0
+
+	CISCO_RD_PREFIX  = "Route Distinguisher:"
+	CISCO_VRF_PREFIX = "VRF:"
+
+	CISCO_VALID_ROUTE    = '*'
+	CISCO_BEST_ROUTE     = '>'
+	CISCO_INTERNAL_ROUTE = 'i'
 
 	SPACE_CHAR = ' '
 
-	CISCO_VALID_ROUTE_OFFSET = 0
-	CISCO_BEST_ROUTE_OFFSET  = 1
+	CISCO_VALID_ROUTE_OFFSET    = 0
+	CISCO_BEST_ROUTE_OFFSET     = 1
+	CISCO_INTERNAL_ROUTE_OFFSET = 2
 )
 
 type rrEntry struct {
 	Prefix string
 	Row    int
-	RRv4   gosnappi.BgpV4RouteRange
-	RRv6   gosnappi.BgpV6RouteRange
+	Rd     string
+	Vrf    string
+	Route  *Route
 	Err    *error
 }
 
+// labelEntry holds labels found in a label table for a prefix / next hop
+type labelEntry struct {
+	Row      int
+	Status   string
+	NextHop  string
+	InLabel  *uint32
+	OutLabel *uint32
+	Vrf      string
+}
+
 type CiscoImporter struct {
 	id uint64
 
@@ -61,21 +89,24 @@ func (imp *CiscoImporter) String() string {
 		imp.id, imp.validRoutes)
 }
 
+// Detect checks for the column header of show ip bgp output
+func (imp *CiscoImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		if strings.HasPrefix(line, CISCO_HEADER_CHECK_STRING) && strings.Contains(line, CISCO_HEADER_NEXT_HOP) &&
+			strings.Contains(line, CISCO_HEADER_PATH) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (imp *CiscoImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
 	if buffer == nil || len(*buffer) == 0 {
 		return nil, fmt.Errorf("cannot import - empty route buffer")
 	}
-
-	imp.startTask = time.Now()
-	imp.lines = strings.Split(string(*buffer), "\n")
-	var next int = 0
-	var err error = nil
-	if next, err = imp.TryParseHeader(); err != nil {
-		return nil, fmt.Errorf("cannot import, header not found - %v", err.Error())
-	}
-	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Header Parsing")
 	if len(ic.Targetv4Peers) > 0 {
-		if len(ic.Targetv4Peers) > 1 {
+		if len(ic.Targetv4Peers) > 1 && !ic.SplitInternal {
 			// To be handled in future
 			return nil, fmt.Errorf("multiple target v4 peers currently not supported")
 		}
@@ -84,8 +115,75 @@ func (imp *CiscoImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]stri
 		return nil, fmt.Errorf("cannot import, no target v4 peers found")
 	}
 
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	labeled := 0
+	// v4 routes only
+	ic.Targetv6Peers = nil
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		if route.InLabel != nil || route.OutLabel != nil {
+			if route.Rd != "" {
+				merger.merger(route).peerV4.Capability().SetIpv4MplsVpn(true)
+			}
+			labeled++
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	if labeled > 0 {
+		// no per route label in gosnappi route range, labels are available from ParseRoutes
+		log.Info().Msgf("labels of %d routes are not advertised, route range has no MPLS label", labeled)
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses valid routes from the buffer without updating any target peer
+func (imp *CiscoImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	imp.lines = strings.Split(string(*buffer), "\n")
+	labels, err := imp.parseLabelTables()
+	if err != nil {
+		return nil, err
+	}
+	var next int = 0
+	if next, err = imp.TryParseHeader(); err != nil {
+		if len(labels) > 0 {
+			// label table only
+			routes, err := processRoutes(&ic, *labelTableRoutes(labels, &ic), DEFAULT_NAME_TEMPLATE)
+			if err != nil {
+				return nil, err
+			}
+			return &routes, nil
+		}
+		return nil, fmt.Errorf("cannot import, header not found - %v", err.Error())
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Header Parsing")
+
 	imp.startTask = time.Now()
-	var prefix string
+	var prefix, rd, vrf, sectionVrf string
+	inLabelTable := false
 	rrEntryList := []rrEntry{}
 	for index := next; index < len(imp.lines); index++ {
 		if strings.ContainsAny(imp.lines[index], "\t") {
@@ -94,8 +192,27 @@ func (imp *CiscoImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]stri
 		if len(imp.lines[index]) == 0 || isSkippableLine(&imp.lines[index]) {
 			continue
 		}
+		if strings.HasPrefix(imp.lines[index], CISCO_HEADER_CHECK_STRING) {
+			// repeated table header, label tables are parsed separately
+			inLabelTable = isLabelHeader(imp.lines[index])
+			prefix = ""
+			continue
+		}
+		if inLabelTable {
+			continue
+		}
+		if strings.HasPrefix(imp.lines[index], CISCO_RD_PREFIX) {
+			rd, vrf = parseRdLine(imp.lines[index], sectionVrf)
+			prefix = ""
+			continue
+		}
+		if strings.HasPrefix(imp.lines[index], CISCO_VRF_PREFIX) {
+			sectionVrf = strings.TrimSpace(imp.lines[index][len(CISCO_VRF_PREFIX):])
+			vrf, rd, prefix = sectionVrf, "", ""
+			continue
+		}
 		pos := imp.POS_CISCO_HEADER_NETWORK
-		if imp.lines[index][pos] != SPACE_CHAR {
+		if len(imp.lines[index]) > pos && imp.lines[index][pos] != SPACE_CHAR {
 			offset := strings.Index(imp.lines[index][pos:], " ")
 			if offset == -1 {
 				prefix = imp.lines[index][pos:]
@@ -110,7 +227,10 @@ func (imp *CiscoImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]stri
 		if ic.BestRoutes && imp.lines[index][CISCO_BEST_ROUTE_OFFSET] != CISCO_BEST_ROUTE {
 			continue
 		}
-		rrEntryList = append(rrEntryList, rrEntry{Prefix: prefix, Row: index})
+		if !isSelectedVrf(&ic, rd, vrf) {
+			continue
+		}
+		rrEntryList = append(rrEntryList, rrEntry{Prefix: prefix, Row: index, Rd: rd, Vrf: vrf})
 	}
 	if ic.SequentialProcess {
 		for i, _ := range rrEntryList {
@@ -127,22 +247,102 @@ func (imp *CiscoImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]stri
 		}
 		wg.Wait()
 	}
-	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Config update")
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
 
-	imp.startTask = time.Now()
-	route_names := []string{}
+	routes := []Route{}
 	for _, rre := range rrEntryList {
-		if rre.RRv4 != nil {
-			imp.PeerV4.V4Routes().Append(rre.RRv4)
-			name := fmt.Sprintf("%s-%d", ic.NamePrefix, rre.Row)
-			route_names = append(route_names, name)
-			imp.validRoutes++
+		if rre.Route != nil {
+			attachLabels(rre.Route, labels)
+			routes = append(routes, *rre.Route)
 		} else {
 			fmt.Printf("No result for row %d\n", rre.Row+1)
 		}
 	}
+	routes, err = processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
 
-	return &route_names, nil
+	return &routes, nil
+}
+
+// parseLabelTables parses label tables ("show bgp ... labels" output) found in the
+// import buffer, returning label entries keyed by route distinguisher and prefix
+func (imp *CiscoImporter) parseLabelTables() (map[string][]labelEntry, error) {
+	labels := map[string][]labelEntry{}
+	var inLabelTable, rcvdFirst bool
+	var posNetwork, posLabel int
+	var prefix, rd, vrf string
+	for index, line := range imp.lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, CISCO_HEADER_CHECK_STRING) {
+			if inLabelTable = isLabelHeader(line); inLabelTable {
+				posNetwork = strings.Index(line, CISCO_HEADER_NETWORK)
+				posLabel = strings.Index(line, CISCO_HEADER_IN_OUT_LABEL)
+				rcvdFirst = posLabel == -1
+				if rcvdFirst {
+					posLabel = strings.Index(line, CISCO_HEADER_RCVD_LABEL)
+				}
+			}
+			prefix = ""
+			continue
+		}
+		if !inLabelTable || len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		if strings.HasPrefix(line, CISCO_RD_PREFIX) {
+			rd, vrf = parseRdLine(line, "")
+			prefix = ""
+			continue
+		}
+		if len(line) <= posNetwork {
+			continue
+		}
+		fields := strings.Fields(line[posNetwork:])
+		if line[posNetwork] != SPACE_CHAR {
+			prefix, fields = fields[0], fields[1:]
+		}
+		if len(fields) == 0 || prefix == "" {
+			// next hop continues in next line
+			continue
+		}
+		ip, mask, err := ParseNetworkAddress(prefix)
+		if err != nil {
+			// not a label row
+			continue
+		}
+		entry := labelEntry{Row: index, Status: strings.TrimSpace(line[:posNetwork]), NextHop: fields[0], Vrf: vrf}
+		if len(line) <= posLabel {
+			return nil, fmt.Errorf("invalid format - missing label (line %d)", index+1)
+		}
+		labelFields := strings.Fields(strings.ReplaceAll(line[posLabel:], "/", " "))
+		if len(labelFields) > 0 {
+			first, err := parseLabel(labelFields[0], index)
+			if err != nil {
+				return nil, err
+			}
+			if rcvdFirst {
+				entry.OutLabel = first
+			} else {
+				entry.InLabel = first
+			}
+		}
+		if len(labelFields) > 1 {
+			second, err := parseLabel(labelFields[1], index)
+			if err != nil {
+				return nil, err
+			}
+			if rcvdFirst {
+				entry.InLabel = second
+			} else {
+				entry.OutLabel = second
+			}
+		}
+		key := labelKey(rd, ip, mask)
+		labels[key] = append(labels[key], entry)
+	}
+
+	return labels, nil
 }
 
 func (imp *CiscoImporter) TryParseHeader() (int, error) {
@@ -217,7 +417,7 @@ func (imp *CiscoImporter) GetHeaderPositions(line string) error {
 func (imp *CiscoImporter) ParseNext(pos int, next int, row *int) string {
 	line := imp.lines[*row]
 	for len(line) <= pos || line[pos-1] != SPACE_CHAR {
-		if len(imp.lines) <= *row || isValidRoute(&imp.lines[*row+1]) {
+		if len(imp.lines) <= *row+1 || isValidRoute(&imp.lines[*row+1]) {
 			return ""
 		}
 		*row = *row + 1
@@ -234,21 +434,21 @@ func (imp *CiscoImporter) ProcessRR(rre *rrEntry, ic *ImportConfig) {
 	var ip net.IP
 	var mask int
 	var err error = nil
-	var rrV4 gosnappi.BgpV4RouteRange = nil
 	network := rre.Prefix
+	best := imp.lines[rre.Row][CISCO_BEST_ROUTE_OFFSET] == CISCO_BEST_ROUTE
+	internal := len(imp.lines[rre.Row]) > CISCO_INTERNAL_ROUTE_OFFSET &&
+		imp.lines[rre.Row][CISCO_INTERNAL_ROUTE_OFFSET] == CISCO_INTERNAL_ROUTE
 
-	nextHop := ""
-	if ic.RetainNexthop {
-		if nextHop = imp.ParseNext(imp.POS_CISCO_HEADER_NEXT_HOP, imp.POS_CISCO_HEADER_METRIC, &rre.Row); nextHop == "" {
-			pErr := fmt.Errorf("no nexthop found (line %d)", rre.Row+1)
-			log.Info().Msgf(pErr.Error())
-			rre.Err = &pErr
-			return
-		}
+	nextHop := imp.ParseNext(imp.POS_CISCO_HEADER_NEXT_HOP, imp.POS_CISCO_HEADER_METRIC, &rre.Row)
+	if ic.RetainNexthop && nextHop == "" {
+		pErr := fmt.Errorf("no nexthop found (line %d)", rre.Row+1)
+		log.Info().Msgf(pErr.Error())
+		rre.Err = &pErr
+		return
 	}
 	metric := imp.ParseNext(imp.POS_CISCO_HEADER_METRIC, imp.POS_CISCO_HEADER_LOC_PRF, &rre.Row)
 	locPrf := imp.ParseNext(imp.POS_CISCO_HEADER_LOC_PRF, imp.POS_CISCO_HEADER_WEIGHT, &rre.Row)
-	// weight := imp.ParseNext(imp.POS_CISCO_HEADER_WEIGHT, imp.POS_CISCO_HEADER_PATH, &rre.Row)
+	weight := imp.ParseNext(imp.POS_CISCO_HEADER_WEIGHT, imp.POS_CISCO_HEADER_PATH, &rre.Row)
 	path := imp.ParseNext(imp.POS_CISCO_HEADER_PATH, len(imp.lines[rre.Row]), &rre.Row)
 
 	if ip, mask, err = ParseNetworkAddress(network); err != nil {
@@ -257,105 +457,159 @@ func (imp *CiscoImporter) ProcessRR(rre *rrEntry, ic *ImportConfig) {
 		rre.Err = &pErr
 		return
 	}
-	name := fmt.Sprintf("%s-%d", ic.NamePrefix, rre.Row+1)
-	if ip.To4() != nil && (ic.RRType == RouteTypeIpv4 || ic.RRType == RouteTypeAuto) {
-		rrV4 = gosnappi.NewBgpV4RouteRange()
-		rrV4.SetName(name)
-		rrV4.Addresses().Add().SetAddress(ip.String()).SetPrefix(uint32(mask))
+	if ip.To4() == nil || (ic.RRType != RouteTypeIpv4 && ic.RRType != RouteTypeAuto) {
+		return
+	}
 
-		// process nexthop
-		if !ic.RetainNexthop {
-			rrV4.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.LOCAL_IP)
-		} else {
-			if err = imp.Processv4Nexthop(rrV4, nextHop, rre.Row); err != nil {
-				rre.Err = &err
-				return
-			}
-		}
+	route := &Route{
+		Row:       rre.Row,
+		Network:   ip,
+		PrefixLen: mask,
+		NextHop:   nextHop,
+		Best:      best,
+		Internal:  internal,
+		Rd:        rre.Rd,
+		Vrf:       rre.Vrf,
+	}
+	if rre.Rd != "" {
+		route.RouteTargets = vrfRouteTargets(ic, rre.Rd, rre.Vrf)
+	}
 
-		// process local Pref
-		if err = imp.Processv4LocalPrf(rrV4, locPrf, rre.Row); err == nil {
-			// process MED
-			if err = imp.Processv4Metric(rrV4, metric, rre.Row); err == nil {
+	// process local Pref
+	if route.LocalPref, err = parseLocalPrf(locPrf, rre.Row); err == nil {
+		// process MED
+		if route.Metric, err = parseMetric(metric, rre.Row); err == nil {
+			// process weight
+			if route.Weight, err = parseWeight(weight, rre.Row); err == nil {
 				// process origin
-				var origin gosnappi.BgpRouteAdvancedOriginEnum
 				if len(path) > 0 {
-					if err, origin = getOriginValue(path[len(path)-1:]); err == nil {
-						rrV4.Advanced().SetIncludeOrigin(true)
-						rrV4.Advanced().SetOrigin(origin)
+					if err, route.Origin = getOriginValue(path[len(path)-1:]); err == nil {
 						// process ASPath
-						err = imp.Processv4AsPath(rrV4, path, rre.Row)
+						route.AsPath, err = parseAsPath(path, rre.Row)
 					}
 				} else {
 					err = fmt.Errorf("found path parameter to be empty (line %d)", rre.Row+1)
 				}
 			}
 		}
-		if err != nil {
-			rre.Err = &err
-		} else {
-			rre.RRv4 = rrV4
-		}
-
-		return
+	}
+	if err != nil {
+		rre.Err = &err
+	} else {
+		rre.Route = route
 	}
 }
 
+// Processv4Nexthop sets the next hop of the route range.
+//
+// Deprecated: route ranges are created from parsed routes, see ParseRoutes.
 func (imp *CiscoImporter) Processv4Nexthop(rr gosnappi.BgpV4RouteRange, nextHop string, row int) error {
 	var ip net.IP
 	if ip = net.ParseIP(nextHop); ip == nil {
 		return fmt.Errorf("invalid ip address: %q for Nexthop processing (line %d)", nextHop, row+1)
 	}
+	rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
 	if ip.To4() != nil {
-		rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
 		rr.SetNextHopIpv4Address(ip.String())
 	} else {
-		rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
 		rr.SetNextHopIpv6Address(ip.String())
 	}
 
 	return nil
 }
 
+// Processv4LocalPrf sets the local preference of the route range.
+//
+// Deprecated: route ranges are created from parsed routes, see ParseRoutes.
 func (imp *CiscoImporter) Processv4LocalPrf(rr gosnappi.BgpV4RouteRange, token string, row int) error {
+	locPrf, err := parseLocalPrf(token, row)
+	if err != nil {
+		return err
+	}
+
+	return setRouteRangeAttributes(&Route{Row: row, LocalPref: locPrf}, rr.Advanced(), rr.AsPath(),
+		false, rr.Communities().Add, rr.ExtCommunities().Add)
+}
+
+// Processv4AsPath sets the AS path of the route range, token followed by the origin code.
+//
+// Deprecated: route ranges are created from parsed routes, see ParseRoutes.
+func (imp *CiscoImporter) Processv4AsPath(rr gosnappi.BgpV4RouteRange, token string, row int) error {
+	asPath, err := parseAsPath(token, row)
+	if err != nil {
+		return err
+	}
+	ebgp := imp.PeerV4 != nil && imp.PeerV4.AsType() == gosnappi.BgpV4PeerAsType.EBGP
+
+	return setRouteRangeAttributes(&Route{Row: row, AsPath: asPath}, rr.Advanced(), rr.AsPath(),
+		ebgp, rr.Communities().Add, rr.ExtCommunities().Add)
+}
+
+// Processv4Metric sets the MED of the route range.
+//
+// Deprecated: route ranges are created from parsed routes, see ParseRoutes.
+func (imp *CiscoImporter) Processv4Metric(rr gosnappi.BgpV4RouteRange, token string, row int) error {
+	metric, err := parseMetric(token, row)
+	if err != nil {
+		return err
+	}
+
+	return setRouteRangeAttributes(&Route{Row: row, Metric: metric}, rr.Advanced(), rr.AsPath(),
+		false, rr.Communities().Add, rr.ExtCommunities().Add)
+}
+
+func parseLocalPrf(token string, row int) (*uint32, error) {
 	if len(token) > 0 {
 		if locprf, err := strconv.Atoi(token); err == nil {
-			rr.Advanced().SetIncludeLocalPreference(true)
-			rr.Advanced().SetLocalPreference(uint32(locprf))
+			value := uint32(locprf)
+			return &value, nil
 		} else {
-			return fmt.Errorf("invalid Local Pref: %q for processing (line %d) - %s", token, row+1, err.Error())
+			return nil, fmt.Errorf("invalid Local Pref: %q for processing (line %d) - %s", token, row+1, err.Error())
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (imp *CiscoImporter) Processv4AsPath(rr gosnappi.BgpV4RouteRange, token string, row int) error {
+func parseWeight(token string, row int) (*uint32, error) {
+	if len(token) > 0 {
+		if weight, err := strconv.ParseUint(token, 10, 16); err == nil {
+			value := uint32(weight)
+			return &value, nil
+		} else {
+			return nil, fmt.Errorf("invalid Weight: %q for processing (line %d) - %s", token, row+1, err.Error())
+		}
+	}
+
+	return nil, nil
+}
+
+func parseAsPath(token string, row int) ([]AsPathSegment, error) {
 	if len(token) <= 2 {
 		// skip line, no as path
-		return nil
+		return nil, nil
 	}
 
-	token = token[:len(token)-2]
+	return parseAsSegments(token[:len(token)-2], row)
+}
+
+// parseAsSegments parses AS path segments without origin code, like "6939 {7545,56203}"
+func parseAsSegments(token string, row int) ([]AsPathSegment, error) {
+	segments := []AsPathSegment{}
 	if len(token) > 0 {
 		token = strings.ReplaceAll(token, ",", " ")
-		asPath := rr.AsPath()
-		if imp.PeerV4.AsType() == gosnappi.BgpV4PeerAsType.EBGP {
-			asPath.SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ)
-		}
 		asNums := strings.Fields(token)
 		var last, cur gosnappi.BgpAsPathSegmentTypeEnum
 		var err error = nil
 		var index int = 0
 		segNums := []uint32{}
-		asSeg := asPath.Segments().Add()
 		last = gosnappi.BgpAsPathSegmentType.AS_SEQ
-		asSeg.SetType(gosnappi.BgpAsPathSegmentTypeEnum(last))
+		asSeg := AsPathSegment{Type: last}
 		for index < len(asNums) {
 			numStr := asNums[index]
 			newSegP, newSegN := false, false
 			if cur, err = getAsPathSegType(numStr[0]); err != nil {
-				return err
+				return nil, err
 			}
 			if last == gosnappi.BgpAsPathSegmentType.AS_SEQ {
 				if cur != gosnappi.BgpAsPathSegmentType.AS_SEQ {
@@ -364,13 +618,17 @@ func (imp *CiscoImporter) Processv4AsPath(rr gosnappi.BgpV4RouteRange, token str
 					last = cur
 				}
 			} else if cur != gosnappi.BgpAsPathSegmentType.AS_SEQ {
-				return fmt.Errorf("incorrect format of as path (line %d)", row+1)
+				return nil, fmt.Errorf("incorrect format of as path (line %d)", row+1)
+			}
+			if numStr == "" {
+				// lone brace, segment delimiters are attached to the AS numbers
+				return nil, fmt.Errorf("incorrect format of as path (line %d)", row+1)
 			}
 			if curT, err := getAsPathSegType(numStr[len(numStr)-1]); err != nil {
-				return err
+				return nil, err
 			} else if curT != gosnappi.BgpAsPathSegmentType.AS_SEQ {
 				if last != curT {
-					return fmt.Errorf("incorrect format of as path (line %d)", row+1)
+					return nil, fmt.Errorf("incorrect format of as path (line %d)", row+1)
 				}
 				newSegP = true
 				numStr = numStr[:len(numStr)-1]
@@ -378,33 +636,33 @@ func (imp *CiscoImporter) Processv4AsPath(rr gosnappi.BgpV4RouteRange, token str
 
 			if newSegN {
 				if len(segNums) > 0 {
-					asSeg.SetAsNumbers(segNums)
+					asSeg.AsNumbers = segNums
+					segments = append(segments, asSeg)
 					segNums = []uint32{}
-					asSeg = asPath.Segments().Add()
 				}
-				asSeg.SetType(gosnappi.BgpAsPathSegmentTypeEnum(cur))
+				asSeg = AsPathSegment{Type: cur}
 			}
-			if asNum, err := strconv.Atoi(numStr); err != nil {
-				return err
+			if asNum, err := parseAsNumber(numStr); err != nil {
+				return nil, fmt.Errorf("%v (line %d)", err, row+1)
 			} else {
-				segNums = append(segNums, uint32(asNum))
+				segNums = append(segNums, asNum)
 			}
 			if newSegP {
-				asSeg.SetAsNumbers(segNums)
+				asSeg.AsNumbers = segNums
+				segments = append(segments, asSeg)
 				segNums = []uint32{}
-				if index+1 < len(asNums) {
-					asSeg = asPath.Segments().Add()
-					last = gosnappi.BgpAsPathSegmentType.AS_SEQ
-				}
+				last = gosnappi.BgpAsPathSegmentType.AS_SEQ
+				asSeg = AsPathSegment{Type: last}
 			}
 			index++
 		}
 		if len(segNums) > 0 {
-			asSeg.SetAsNumbers(segNums)
+			asSeg.AsNumbers = segNums
+			segments = append(segments, asSeg)
 		}
 	}
 
-	return nil
+	return segments, nil
 }
 
 func getAsPathSegType(b byte) (gosnappi.BgpAsPathSegmentTypeEnum, error) {
@@ -429,18 +687,19 @@ func getAsPathSegType(b byte) (gosnappi.BgpAsPathSegmentTypeEnum, error) {
 	return gosnappi.BgpAsPathSegmentType.AS_SEQ, fmt.Errorf("Invalid aspath segment marker %v", b)
 }
 
-func (imp *CiscoImporter) Processv4Metric(rr gosnappi.BgpV4RouteRange, token string, row int) error {
+func parseMetric(token string, row int) (*uint32, error) {
 	if len(token) > 0 {
 		if med, err := strconv.Atoi(token); err == nil {
-			rr.Advanced().SetIncludeMultiExitDiscriminator(true)
-			rr.Advanced().SetMultiExitDiscriminator(uint32(med))
+			value := uint32(med)
+			return &value, nil
 		} else {
-			return fmt.Errorf("invalid MED: %q for processing at row %d, error: %s", token, row+1, err.Error())
+			return nil, fmt.Errorf("invalid MED: %q for processing at row %d, error: %s", token, row+1, err.Error())
 		}
 	}
 
-	return nil
+	return nil, nil
 }
+
 func getOriginValue(origin string) (error, gosnappi.BgpRouteAdvancedOriginEnum) {
 	origin = strings.Trim(origin, " ")
 	if len(origin) > 0 {
@@ -473,6 +732,13 @@ func ParseNetworkAddress(line string) (net.IP, int, error) {
 				return nil, mask, err
 			}
 		}
+		max := 128
+		if ip.To4() != nil {
+			max = 32
+		}
+		if mask < 0 || mask > max {
+			return nil, mask, fmt.Errorf("invalid prefix length: %q", line)
+		}
 	} else {
 		return nil, mask, fmt.Errorf("not valid network address : %q", line)
 	}
@@ -486,5 +752,110 @@ func isSkippableLine(line *string) bool {
 }
 
 func isValidRoute(line *string) bool {
-	return (*line)[CISCO_VALID_ROUTE_OFFSET] == CISCO_VALID_ROUTE
+	return len(*line) > CISCO_VALID_ROUTE_OFFSET && (*line)[CISCO_VALID_ROUTE_OFFSET] == CISCO_VALID_ROUTE
+}
+
+func isLabelHeader(line string) bool {
+	return strings.Contains(line, CISCO_HEADER_IN_OUT_LABEL) ||
+		(strings.Contains(line, CISCO_HEADER_RCVD_LABEL) && strings.Contains(line, CISCO_HEADER_LOCAL_LABEL))
+}
+
+// parseLabel converts label column value, returning nil for absent label
+func parseLabel(token string, row int) (*uint32, error) {
+	var label uint32
+	switch token {
+	case CISCO_NO_LABEL:
+		return nil, nil
+	case CISCO_IMPLICIT_NULL_LABEL:
+		label = MPLS_IMPLICIT_NULL_LABEL
+	case CISCO_EXPLICIT_NULL_LABEL:
+		label = MPLS_EXPLICIT_NULL_LABEL
+	default:
+		value, err := strconv.ParseUint(token, 10, 20)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label: %q (line %d)", token, row+1)
+		}
+		label = uint32(value)
+	}
+
+	return &label, nil
+}
+
+func labelKey(rd string, ip net.IP, mask int) string {
+	return fmt.Sprintf("%s|%s/%d", rd, ip.String(), mask)
+}
+
+// attachLabels sets labels of the route from the label entry with same
+// route distinguisher, prefix and next hop (or first entry of the prefix)
+func attachLabels(route *Route, labels map[string][]labelEntry) {
+	entries := labels[labelKey(route.Rd, route.Network, route.PrefixLen)]
+	if len(entries) == 0 {
+		return
+	}
+	entry := entries[0]
+	for _, e := range entries {
+		if e.NextHop == route.NextHop {
+			entry = e
+			break
+		}
+	}
+	route.InLabel, route.OutLabel = entry.InLabel, entry.OutLabel
+}
+
+// labelTableRoutes creates routes from label tables when import buffer has no route table
+func labelTableRoutes(labels map[string][]labelEntry, ic *ImportConfig) *[]Route {
+	routes := []Route{}
+	for key, entries := range labels {
+		rd := key[:strings.Index(key, "|")]
+		ip, mask, _ := ParseNetworkAddress(key[len(rd)+1:])
+		if ip.To4() == nil || (ic.RRType != RouteTypeIpv4 && ic.RRType != RouteTypeAuto) {
+			continue
+		}
+		for _, entry := range entries {
+			best := entry.Status == "" || strings.ContainsRune(entry.Status, CISCO_BEST_ROUTE)
+			if (ic.BestRoutes && !best) || !isSelectedVrf(ic, rd, entry.Vrf) {
+				continue
+			}
+			route := Route{
+				Row:       entry.Row,
+				Network:   ip,
+				PrefixLen: mask,
+				NextHop:   entry.NextHop,
+				Best:      best,
+				Internal:  strings.ContainsRune(entry.Status, CISCO_INTERNAL_ROUTE),
+				Rd:        rd,
+				Vrf:       entry.Vrf,
+				InLabel:   entry.InLabel,
+				OutLabel:  entry.OutLabel,
+			}
+			if rd != "" {
+				route.RouteTargets = vrfRouteTargets(ic, rd, entry.Vrf)
+			}
+			routes = append(routes, route)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Row < routes[j].Row })
+
+	return &routes
+}
+
+// parseRdLine extracts route distinguisher and vrf name from a line like
+// "Route Distinguisher: 65000:1 (default for vrf RED)"
+func parseRdLine(line string, vrf string) (string, string) {
+	fields := strings.Fields(line[len(CISCO_RD_PREFIX):])
+	if len(fields) == 0 {
+		return "", vrf
+	}
+	rd := fields[0]
+	if len(fields) == 2 && strings.HasPrefix(fields[1], "(") {
+		// "Route Distinguisher: 100:1 (RED)"
+		return rd, strings.Trim(fields[1], "()")
+	}
+	for i, field := range fields[1:] {
+		if strings.EqualFold(strings.TrimLeft(field, "("), "vrf") && i+2 < len(fields) {
+			return rd, strings.TrimRight(fields[i+2], ")")
+		}
+	}
+
+	return rd, vrf
 }
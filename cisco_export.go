@@ -0,0 +1,203 @@
+package routeimporter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CISCO_EXPORT_BANNER = "BGP table version is 1, local router ID is 0.0.0.0\n" +
+		"Status codes: s suppressed, d damped, h history, * valid, > best, i - internal,\n" +
+		"              r RIB-failure, S Stale, m multipath, b backup-path, x best-external\n" +
+		"Origin codes: i - IGP, e - EGP, ? - incomplete\n\n"
+	CISCO_EXPORT_HEADER = "   Network          Next Hop            Metric LocPrf Weight Path"
+)
+
+// CiscoExporter renders routes as Cisco show ip bgp table, in the format
+// CiscoImporter reads back
+type CiscoExporter struct {
+	id uint64
+
+	//
+	POS_CISCO_HEADER_NETWORK  int
+	POS_CISCO_HEADER_NEXT_HOP int
+	POS_CISCO_HEADER_METRIC   int
+	POS_CISCO_HEADER_LOC_PRF  int
+	POS_CISCO_HEADER_WEIGHT   int
+	POS_CISCO_HEADER_PATH     int
+
+	exportedRoutes int
+}
+
+// String returns the id of the client.
+func (exp *CiscoExporter) String() string {
+	return fmt.Sprintf("Cisco Route Exporter, session id: %8d, exportedRoutes:%d",
+		exp.id, exp.exportedRoutes)
+}
+
+func (exp *CiscoExporter) ExportRoutes(routes *[]Route) (*[]byte, error) {
+	if routes == nil {
+		return nil, fmt.Errorf("cannot export - no routes")
+	}
+	header := CiscoImporter{}
+	if err := header.GetHeaderPositions(CISCO_EXPORT_HEADER); err != nil {
+		return nil, err
+	}
+	exp.POS_CISCO_HEADER_NETWORK = header.POS_CISCO_HEADER_NETWORK
+	exp.POS_CISCO_HEADER_NEXT_HOP = header.POS_CISCO_HEADER_NEXT_HOP
+	exp.POS_CISCO_HEADER_METRIC = header.POS_CISCO_HEADER_METRIC
+	exp.POS_CISCO_HEADER_LOC_PRF = header.POS_CISCO_HEADER_LOC_PRF
+	exp.POS_CISCO_HEADER_WEIGHT = header.POS_CISCO_HEADER_WEIGHT
+	exp.POS_CISCO_HEADER_PATH = header.POS_CISCO_HEADER_PATH
+
+	sorted := []*Route{}
+	expanded := expandRoutes(*routes)
+	for i := range expanded {
+		route := &expanded[i]
+		if route.Evpn != nil || route.Network == nil {
+			log.Info().Msgf("route %s not exported, EVPN routes not supported", route.Name)
+			continue
+		}
+		sorted = append(sorted, route)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Rd != sorted[j].Rd {
+			return sorted[i].Rd < sorted[j].Rd
+		}
+		if c := bytes.Compare(normalizeIP(sorted[i].Network), normalizeIP(sorted[j].Network)); c != 0 {
+			return c < 0
+		}
+		return sorted[i].PrefixLen < sorted[j].PrefixLen
+	})
+
+	var b strings.Builder
+	b.WriteString(CISCO_EXPORT_BANNER)
+	b.WriteString(CISCO_EXPORT_HEADER + "\n")
+	last := ""
+	rd := ""
+	for _, route := range sorted {
+		if route.Rd != rd {
+			rd = route.Rd
+			if route.Vrf != "" {
+				b.WriteString(fmt.Sprintf("%s %s (default for vrf %s)\n", CISCO_RD_PREFIX, rd, route.Vrf))
+			} else {
+				b.WriteString(fmt.Sprintf("%s %s\n", CISCO_RD_PREFIX, rd))
+			}
+			last = ""
+		}
+		network := fmt.Sprintf("%s/%d", route.Network.String(), route.PrefixLen)
+		if network == last {
+			// further paths of same network leave network column empty
+			network = ""
+		} else {
+			last = network
+		}
+		b.WriteString(exp.formatRow(route, network))
+		exp.exportedRoutes++
+	}
+	buffer := []byte(b.String())
+
+	return &buffer, nil
+}
+
+// formatRow renders one route, continuing on next line where a column value
+// overflows into the next column
+func (exp *CiscoExporter) formatRow(route *Route, network string) string {
+	var b strings.Builder
+	line := string(CISCO_VALID_ROUTE)
+	if route.Best {
+		line += string(CISCO_BEST_ROUTE)
+	} else {
+		line += " "
+	}
+	if route.Internal {
+		line += string(CISCO_INTERNAL_ROUTE)
+	}
+	line = padRight(line, exp.POS_CISCO_HEADER_NETWORK) + network
+
+	line = exp.appendColumn(&b, line, route.NextHop, exp.POS_CISCO_HEADER_NEXT_HOP, false)
+	metric, locPrf, weight := "", "", "0"
+	if route.Metric != nil {
+		metric = fmt.Sprint(*route.Metric)
+	}
+	if route.LocalPref != nil {
+		locPrf = fmt.Sprint(*route.LocalPref)
+	}
+	if route.Weight != nil {
+		weight = fmt.Sprint(*route.Weight)
+	}
+	line = exp.appendColumn(&b, line, metric, exp.POS_CISCO_HEADER_METRIC+len(CISCO_HEADER_METRIC), true)
+	line = exp.appendColumn(&b, line, locPrf, exp.POS_CISCO_HEADER_LOC_PRF+len(CISCO_HEADER_LOC_PRF), true)
+	line = exp.appendColumn(&b, line, weight, exp.POS_CISCO_HEADER_WEIGHT+len(CISCO_HEADER_WEIGHT), true)
+	line = exp.appendColumn(&b, line, formatAsPath(route), exp.POS_CISCO_HEADER_PATH, false)
+	b.WriteString(line + "\n")
+
+	return b.String()
+}
+
+// appendColumn appends value starting at pos, or right aligned ending at pos.
+// If current line already reaches the column, the line is flushed to b and value
+// is placed in a new line.
+func (exp *CiscoExporter) appendColumn(b *strings.Builder, line string, value string, pos int, rightAlign bool) string {
+	start := pos
+	if rightAlign {
+		start = pos - len(value)
+	}
+	if len(line) >= start {
+		b.WriteString(line + "\n")
+		line = ""
+	}
+
+	return padRight(line, start) + value
+}
+
+func padRight(line string, width int) string {
+	if len(line) >= width {
+		return line
+	}
+
+	return line + strings.Repeat(" ", width-len(line))
+}
+
+func normalizeIP(ip []byte) []byte {
+	if v4 := ([]byte)(ip); len(v4) == 16 && bytes.Equal(v4[:12], []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff}) {
+		return v4[12:]
+	}
+	return ip
+}
+
+// formatAsPath renders AS path segments and origin code as in Path column
+func formatAsPath(route *Route) string {
+	tokens := []string{}
+	for _, seg := range route.AsPath {
+		nums := []string{}
+		for _, num := range seg.AsNumbers {
+			nums = append(nums, fmt.Sprint(num))
+		}
+		switch seg.Type {
+		case gosnappi.BgpAsPathSegmentType.AS_SET:
+			tokens = append(tokens, "{"+strings.Join(nums, ",")+"}")
+		case gosnappi.BgpAsPathSegmentType.AS_CONFED_SET:
+			tokens = append(tokens, "["+strings.Join(nums, ",")+"]")
+		case gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ:
+			tokens = append(tokens, "("+strings.Join(nums, " ")+")")
+		default:
+			tokens = append(tokens, nums...)
+		}
+	}
+	switch route.Origin {
+	case gosnappi.BgpRouteAdvancedOrigin.IGP:
+		tokens = append(tokens, "i")
+	case gosnappi.BgpRouteAdvancedOrigin.EGP:
+		tokens = append(tokens, "e")
+	default:
+		tokens = append(tokens, "?")
+	}
+
+	return strings.Join(tokens, " ")
+}
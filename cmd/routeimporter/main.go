@@ -0,0 +1,176 @@
+// Command routeimporter inspects route tables supported by the routeimporter library.
+//
+// Usage:
+//
+//	routeimporter analyze [-format cisco|auto] [-json] [-top 10] <file>
+//	routeimporter generate [-v4 1000] [-v6 0] [-seed 1] [-profile profile.json | -learn <file> [-format cisco]] [-export cisco]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/rs/zerolog"
+)
+
+const FORMAT_AUTO = "auto"
+
+func main() {
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "analyze":
+		err = analyze(os.Args[2:])
+	case "generate":
+		err = generate(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [options] <file>\n\nCommands:\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "  analyze    report statistics of a route table\n")
+	fmt.Fprintf(os.Stderr, "  generate   write a synthetic Internet-like route table\n")
+}
+
+func formatNames() string {
+	return strings.Join(append(routeimporter.ImporterNames(), FORMAT_AUTO), ", ")
+}
+
+// parseFile parses routes of an import file of the named format, or of the detected format
+// for auto
+func parseFile(filename string, format string, bestRoutes bool) (*[]routeimporter.Route, error) {
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var is routeimporter.ImportService
+	if format == FORMAT_AUTO {
+		is, _, err = routeimporter.DetectImporterService(&fb)
+	} else {
+		is, err = routeimporter.GetImporterServiceByName(format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "route", BestRoutes: bestRoutes}, &fb)
+}
+
+func analyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	format := fs.String("format", "cisco", "import file format: "+formatNames())
+	asJson := fs.Bool("json", false, "output in JSON format")
+	top := fs.Int("top", routeimporter.DEFAULT_ANALYZE_TOP, "number of top origin ASes")
+	bestRoutes := fs.Bool("best", false, "analyze best routes only")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("analyze: expected one import file, found %d", fs.NArg())
+	}
+
+	routes, err := parseFile(fs.Arg(0), *format, *bestRoutes)
+	if err != nil {
+		return fmt.Errorf("analyze: %v", err)
+	}
+	stats, err := routeimporter.Analyze(routes, *top)
+	if err != nil {
+		return fmt.Errorf("analyze: %v", err)
+	}
+
+	if *asJson {
+		js, err := stats.Json()
+		if err != nil {
+			return fmt.Errorf("analyze: %v", err)
+		}
+		fmt.Println(string(js))
+	} else {
+		fmt.Print(stats.String())
+	}
+
+	return nil
+}
+
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	v4 := fs.Int("v4", 1000, "number of IPv4 routes")
+	v6 := fs.Int("v6", 0, "number of IPv6 routes")
+	seed := fs.Int64("seed", 1, "random seed")
+	profileFile := fs.String("profile", "", "table profile JSON file")
+	learn := fs.String("learn", "", "route table to learn the profile from")
+	format := fs.String("format", "cisco", "format of the learned route table: "+formatNames())
+	export := fs.String("export", "cisco", "output format: cisco, mrt")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		return fmt.Errorf("generate: unexpected arguments %v", fs.Args())
+	}
+
+	g := routeimporter.TableGenerator{Ipv4Routes: *v4, Ipv6Routes: *v6, Seed: *seed}
+	switch {
+	case *profileFile != "" && *learn != "":
+		return fmt.Errorf("generate: -profile and -learn are exclusive")
+	case *profileFile != "":
+		fb, err := os.ReadFile(*profileFile)
+		if err != nil {
+			return fmt.Errorf("generate: %v", err)
+		}
+		g.Profile = &routeimporter.TableProfile{}
+		if err := json.Unmarshal(fb, g.Profile); err != nil {
+			return fmt.Errorf("generate: invalid profile %s: %v", *profileFile, err)
+		}
+	case *learn != "":
+		routes, err := parseFile(*learn, *format, false)
+		if err != nil {
+			return fmt.Errorf("generate: %v", err)
+		}
+		stats, err := routeimporter.Analyze(routes, routeimporter.DEFAULT_ANALYZE_TOP)
+		if err != nil {
+			return fmt.Errorf("generate: %v", err)
+		}
+		if g.Profile, err = routeimporter.ProfileFromStats(stats); err != nil {
+			return fmt.Errorf("generate: %v", err)
+		}
+	}
+
+	exportType := routeimporter.ExportFileTypeCisco
+	switch *export {
+	case "cisco":
+	case "mrt":
+		exportType = routeimporter.ExportFileTypeMrt
+	default:
+		return fmt.Errorf("generate: unknown export format %q, supported formats: cisco, mrt", *export)
+	}
+	routes, err := g.GenerateRoutes(routeimporter.ImportConfig{NamePrefix: "route"})
+	if err != nil {
+		return fmt.Errorf("generate: %v", err)
+	}
+	es, err := routeimporter.GetExporterService(exportType)
+	if err != nil {
+		return fmt.Errorf("generate: %v", err)
+	}
+	out, err := es.ExportRoutes(routes)
+	if err != nil {
+		return fmt.Errorf("generate: %v", err)
+	}
+	_, err = os.Stdout.Write(*out)
+
+	return err
+}
@@ -0,0 +1,320 @@
+package routeimporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CSV_FIELD_PREFIX      = "prefix"      // network/length, required
+	CSV_FIELD_NEXT_HOP    = "next_hop"    // next hop address
+	CSV_FIELD_AS_PATH     = "as_path"     // AS path without origin, e.g. "6939 {7545,56203}"
+	CSV_FIELD_ORIGIN      = "origin"      // i / igp, e / egp, ? / incomplete
+	CSV_FIELD_MED         = "med"         // multi exit discriminator
+	CSV_FIELD_LOCAL_PREF  = "local_pref"  // local preference
+	CSV_FIELD_COMMUNITIES = "communities" // space separated communities, e.g. "65000:100 no-export"
+	CSV_FIELD_PATH_ID     = "path_id"     // add-path path identifier
+
+	CSV_COMMENT = '#'
+)
+
+// CSV_DEFAULT_COLUMNS are the columns of CSV route lists without header line
+var CSV_DEFAULT_COLUMNS = map[string]int{
+	CSV_FIELD_PREFIX:      0,
+	CSV_FIELD_NEXT_HOP:    1,
+	CSV_FIELD_AS_PATH:     2,
+	CSV_FIELD_MED:         3,
+	CSV_FIELD_LOCAL_PREF:  4,
+	CSV_FIELD_COMMUNITIES: 5,
+}
+
+// csvHeaderNames maps normalized header names to fields
+var csvHeaderNames = map[string]string{
+	"prefix":      CSV_FIELD_PREFIX,
+	"network":     CSV_FIELD_PREFIX,
+	"route":       CSV_FIELD_PREFIX,
+	"next_hop":    CSV_FIELD_NEXT_HOP,
+	"nexthop":     CSV_FIELD_NEXT_HOP,
+	"as_path":     CSV_FIELD_AS_PATH,
+	"aspath":      CSV_FIELD_AS_PATH,
+	"path":        CSV_FIELD_AS_PATH,
+	"origin":      CSV_FIELD_ORIGIN,
+	"med":         CSV_FIELD_MED,
+	"metric":      CSV_FIELD_MED,
+	"local_pref":  CSV_FIELD_LOCAL_PREF,
+	"localpref":   CSV_FIELD_LOCAL_PREF,
+	"locprf":      CSV_FIELD_LOCAL_PREF,
+	"communities": CSV_FIELD_COMMUNITIES,
+	"community":   CSV_FIELD_COMMUNITIES,
+	"path_id":     CSV_FIELD_PATH_ID,
+	"pathid":      CSV_FIELD_PATH_ID,
+}
+
+// CsvImporter imports routes from CSV / TSV route lists
+type CsvImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+	PeerV4      gosnappi.BgpV4Peer
+	PeerV6      gosnappi.BgpV6Peer
+}
+
+// String returns the id of the client.
+func (imp *CsvImporter) String() string {
+	return fmt.Sprintf("CSV Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for a header line naming the prefix column and another route field, or for
+// a prefix in the first column of the first line
+func (imp *CsvImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		if len(strings.TrimSpace(line)) == 0 || line[0] == CSV_COMMENT {
+			continue
+		}
+		fields := strings.Split(line, string(csvDelimiter(line)))
+		if len(fields) < 2 {
+			return false
+		}
+		if columns := csvHeaderColumns(fields); columns != nil {
+			return len(columns) > 1
+		}
+		_, _, err := parsePrefix(strings.TrimSpace(fields[0]))
+		return err == nil
+	}
+
+	return false
+}
+
+// ImportRoutes adds one route range per listed route. IPv4 routes are added to the target
+// v4 peer and IPv6 routes to the target v6 peer.
+func (imp *CsvImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	if !ic.SplitInternal {
+		peerV4, peerV6, err := targetPeers(&ic)
+		if err != nil {
+			return nil, err
+		}
+		imp.PeerV4, imp.PeerV6 = peerV4, peerV6
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses the route list, with columns of the CSV config of the import config,
+// of the header line if there is one, or CSV_DEFAULT_COLUMNS. Rows with invalid fields are skipped.
+func (imp *CsvImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	config := CsvConfig{}
+	if ic.Csv != nil {
+		config = *ic.Csv
+	}
+	if config.Delimiter == 0 {
+		first := *buffer
+		if end := bytes.IndexByte(first, '\n'); end != -1 {
+			first = first[:end]
+		}
+		config.Delimiter = csvDelimiter(string(first))
+	}
+	reader := csv.NewReader(bytes.NewReader(*buffer))
+	reader.Comma = config.Delimiter
+	reader.Comment = CSV_COMMENT
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	columns := config.Columns
+	routes := []Route{}
+	for record := 0; ; record++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot import - %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if record == 0 {
+			if header := csvHeaderColumns(fields); header != nil {
+				if _, ok := header[CSV_FIELD_PREFIX]; !ok && columns == nil {
+					return nil, fmt.Errorf("cannot import, no prefix column in header (line %d)", line)
+				}
+				if columns == nil {
+					columns = header
+				}
+				continue
+			}
+		}
+		if columns == nil {
+			columns = CSV_DEFAULT_COLUMNS
+		}
+
+		route, err := parseCsvRoute(fields, columns, line-1)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+			(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+			continue
+		}
+		routes = append(routes, *route)
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+	routes, err := processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// parseCsvRoute parses the fields of a route list row
+func parseCsvRoute(fields []string, columns map[string]int, row int) (*Route, error) {
+	field := func(name string) string {
+		if index, ok := columns[name]; ok && index >= 0 && index < len(fields) {
+			return strings.TrimSpace(fields[index])
+		}
+		return ""
+	}
+	value := func(name string) (*uint32, error) {
+		token := field(name)
+		if token == "" {
+			return nil, nil
+		}
+		num, err := strconv.ParseUint(token, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q (line %d)", name, token, row+1)
+		}
+		v := uint32(num)
+		return &v, nil
+	}
+
+	route := &Route{Row: row, NextHop: field(CSV_FIELD_NEXT_HOP)}
+	var err error
+	if route.Network, route.PrefixLen, err = parsePrefix(field(CSV_FIELD_PREFIX)); err != nil {
+		return nil, fmt.Errorf("%v (line %d)", err, row+1)
+	}
+	if route.AsPath, err = parseAsSegments(field(CSV_FIELD_AS_PATH), row); err != nil {
+		return nil, err
+	}
+	if origin := field(CSV_FIELD_ORIGIN); origin != "" {
+		if route.Origin, err = parseOriginName(origin); err != nil {
+			return nil, fmt.Errorf("%v (line %d)", err, row+1)
+		}
+	}
+	if route.Metric, err = value(CSV_FIELD_MED); err != nil {
+		return nil, err
+	}
+	if route.LocalPref, err = value(CSV_FIELD_LOCAL_PREF); err != nil {
+		return nil, err
+	}
+	if pathId, err := value(CSV_FIELD_PATH_ID); err != nil {
+		return nil, err
+	} else if pathId != nil {
+		route.PathId = *pathId
+	}
+	for _, c := range strings.Fields(field(CSV_FIELD_COMMUNITIES)) {
+		if _, err := communityValue(c); err != nil {
+			return nil, fmt.Errorf("%v (line %d)", err, row+1)
+		}
+		route.Communities = append(route.Communities, c)
+	}
+
+	return route, nil
+}
+
+// parsePrefix parses a network/length prefix of route lists
+func parsePrefix(prefix string) (net.IP, int, error) {
+	if !strings.Contains(prefix, "/") {
+		return nil, 0, fmt.Errorf("invalid prefix: %q", prefix)
+	}
+
+	return ParseNetworkAddress(prefix)
+}
+
+// parseOriginName parses an origin code (i, e, ?) or name (igp, egp, incomplete)
+func parseOriginName(origin string) (gosnappi.BgpRouteAdvancedOriginEnum, error) {
+	switch strings.ToLower(origin) {
+	case "i", "igp":
+		return gosnappi.BgpRouteAdvancedOrigin.IGP, nil
+	case "e", "egp":
+		return gosnappi.BgpRouteAdvancedOrigin.EGP, nil
+	case "?", "incomplete":
+		return gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE, nil
+	}
+
+	return "", fmt.Errorf("unknown origin string: %q", origin)
+}
+
+// csvHeaderColumns returns column indexes of route fields named by a header line,
+// nil if the fields are no header
+func csvHeaderColumns(fields []string) map[string]int {
+	columns := map[string]int{}
+	for i, name := range fields {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+		if field, ok := csvHeaderNames[name]; ok {
+			if _, found := columns[field]; !found {
+				columns[field] = i
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return columns
+}
+
+// csvDelimiter returns the delimiter found in the first line of a route list
+func csvDelimiter(line string) rune {
+	if strings.Contains(line, "\t") {
+		return '\t'
+	}
+	if strings.Contains(line, ";") && !strings.Contains(line, ",") {
+		return ';'
+	}
+
+	return ','
+}
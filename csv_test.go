@@ -0,0 +1,146 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesCsv(t *testing.T) {
+	filename := "resource/routes.csv"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCsv)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "csv"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	// rows without prefix length or with invalid MED are skipped
+	if len(*routes) != 3 {
+		t.Errorf("Expected 3 routes, found %d", len(*routes))
+		return
+	}
+	first, second, third := (*routes)[0], (*routes)[1], (*routes)[2]
+	if first.Name != "csv-3" || first.Network.String() != "1.0.0.0" || first.PrefixLen != 24 || first.NextHop != "192.0.2.1" ||
+		*first.Metric != 50 || *first.LocalPref != 200 || first.Origin != gosnappi.BgpRouteAdvancedOrigin.IGP ||
+		!reflect.DeepEqual(first.Communities, []string{"65000:100", "no-export"}) {
+		t.Errorf("Unexpected first route %+v", first)
+	}
+	expectedPath := []routeimporter.AsPathSegment{
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{6939}},
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{7545, 56203}},
+	}
+	if !reflect.DeepEqual(second.AsPath, expectedPath) || second.Origin != gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE ||
+		second.Metric != nil || second.LocalPref != nil || second.Communities != nil {
+		t.Errorf("Unexpected second route %+v", second)
+	}
+	if third.Network.String() != "2001:db8:100::" || third.PrefixLen != 48 || third.AsPath[0].AsNumbers[0] != 4259840100 {
+		t.Errorf("Unexpected third route %+v", third)
+	}
+
+	config := gosnappi.NewConfig()
+	bgp := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1")
+	peerV4 := bgp.Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peerV4.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	peerV6 := bgp.Ipv6Interfaces().Add().SetIpv6Name("intB").Peers().Add().SetName("peerB")
+	peerV6.SetPeerAddress("::1").SetAsType(gosnappi.BgpV6PeerAsType.IBGP).SetAsNumber(65001)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "csv",
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peerV4},
+		Targetv6Peers: []gosnappi.BgpV6Peer{peerV6},
+	}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 3 || len(peerV4.V4Routes().Items()) != 2 || len(peerV6.V6Routes().Items()) != 1 {
+		t.Errorf("Expected 2 v4 and 1 v6 route ranges, found %d, %d", len(peerV4.V4Routes().Items()),
+			len(peerV6.V6Routes().Items()))
+	}
+}
+
+func TestParseRoutesTsv(t *testing.T) {
+	filename := "resource/routes.tsv"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCsv)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	// default columns without header line
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "tsv", RRType: routeimporter.RouteTypeIpv4}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 1 || *(*routes)[0].Metric != 50 || *(*routes)[0].LocalPref != 200 ||
+		!reflect.DeepEqual((*routes)[0].Communities, []string{"65000:100"}) {
+		t.Errorf("Expected 1 IPv4 route with MED, local pref and community, found %+v", *routes)
+	}
+
+	// column map
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "tsv",
+		Csv: &routeimporter.CsvConfig{
+			Delimiter: '\t',
+			Columns:   map[string]int{routeimporter.CSV_FIELD_PREFIX: 0, routeimporter.CSV_FIELD_LOCAL_PREF: 3},
+		},
+	}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 2 || *(*routes)[0].LocalPref != 50 || (*routes)[0].Metric != nil || (*routes)[0].AsPath == nil ||
+		len((*routes)[0].AsPath) != 0 || (*routes)[1].LocalPref != nil {
+		t.Errorf("Expected 2 routes with local pref from MED column, found %+v", *routes)
+	}
+
+	if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "csv" {
+		t.Errorf("Expected csv format detected, found %q, error: %v", name, err)
+	}
+}
+
+func TestParseRoutesCsvLoneBrace(t *testing.T) {
+	// rows with braces apart from AS numbers are skipped
+	fb := []byte("prefix,next_hop,as_path\n" +
+		"1.0.0.0/24,192.0.2.1,65001 { 65010 }\n" +
+		"1.0.1.0/24,192.0.2.1,65001 {\n" +
+		"1.0.2.0/24,192.0.2.1,65001 }\n" +
+		"1.0.3.0/24,192.0.2.1,65001 {65010}\n")
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCsv)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "csv"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 1 || (*routes)[0].Network.String() != "1.0.3.0" {
+		t.Errorf("Expected route 1.0.3.0/24 only, found %d routes", len(*routes))
+	}
+}
@@ -0,0 +1,236 @@
+package routeimporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DiffTables compares routes of two tables, as returned by ParseRoutes of any importer.
+// Routes are matched by route distinguisher, prefix and path id; multiple paths of a
+// prefix without path id are matched to paths with same attributes first, remaining
+// paths are matched in table order.
+func DiffTables(oldRoutes *[]Route, newRoutes *[]Route) (*TableDiff, error) {
+	if oldRoutes == nil || newRoutes == nil {
+		return nil, fmt.Errorf("cannot diff - no routes")
+	}
+
+	startTask := time.Now()
+	diff := &TableDiff{}
+	oldPaths := map[string][]*Route{}
+	keys := []string{}
+	expandedOld := expandRoutes(*oldRoutes)
+	for i := range expandedOld {
+		route := &expandedOld[i]
+		key := routeKey(route)
+		if _, ok := oldPaths[key]; !ok {
+			keys = append(keys, key)
+		}
+		oldPaths[key] = append(oldPaths[key], route)
+	}
+
+	newPaths := map[string][]*Route{}
+	expandedNew := expandRoutes(*newRoutes)
+	for i := range expandedNew {
+		route := &expandedNew[i]
+		key := routeKey(route)
+		newPaths[key] = append(newPaths[key], route)
+	}
+
+	matched := map[*Route]*Route{}
+	for key, paths := range newPaths {
+		candidates := oldPaths[key]
+		used := make([]bool, len(candidates))
+		// same attributes
+		for _, route := range paths {
+			for j, candidate := range candidates {
+				if !used[j] && len(routeChanges(candidate, route)) == 0 {
+					used[j] = true
+					matched[route] = candidate
+					break
+				}
+			}
+		}
+		// changed attributes
+		for _, route := range paths {
+			if matched[route] != nil {
+				continue
+			}
+			for j, candidate := range candidates {
+				if !used[j] {
+					used[j] = true
+					matched[route] = candidate
+					break
+				}
+			}
+		}
+	}
+
+	found := map[*Route]bool{}
+	for i := range expandedNew {
+		route := &expandedNew[i]
+		candidate := matched[route]
+		if candidate == nil {
+			diff.Added = append(diff.Added, *route)
+			continue
+		}
+		found[candidate] = true
+		if changes := routeChanges(candidate, route); len(changes) > 0 {
+			diff.Modified = append(diff.Modified, RouteChange{Old: *candidate, New: *route, Changes: changes})
+		} else {
+			diff.Unchanged++
+		}
+	}
+	for _, key := range keys {
+		for _, route := range oldPaths[key] {
+			if !found[route] {
+				diff.Removed = append(diff.Removed, *route)
+			}
+		}
+	}
+	log.Info().Int64("milisecs", time.Since(startTask).Milliseconds()).Msg("Table diff")
+
+	return diff, nil
+}
+
+// ImportChanges adds route ranges of added and modified routes to the target v4 peer
+// (IPv4 routes) and v6 peer (IPv6 routes). Removed routes have no route range.
+func (diff *TableDiff) ImportChanges(ic ImportConfig) (*[]string, error) {
+	routes := append([]Route{}, diff.Added...)
+	for _, change := range diff.Modified {
+		routes = append(routes, change.New)
+	}
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range routes {
+		route := &routes[i]
+		if route.Evpn != nil || route.Network == nil {
+			log.Info().Msgf("route %s not imported, EVPN routes not supported", route.Name)
+			continue
+		}
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+	}
+	merger.done()
+
+	return &route_names, nil
+}
+
+// String returns the diff report, one line per added, removed or modified route
+func (diff *TableDiff) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Added: %d, Removed: %d, Modified: %d, Unchanged: %d\n",
+		len(diff.Added), len(diff.Removed), len(diff.Modified), diff.Unchanged))
+	for _, route := range diff.Added {
+		b.WriteString(fmt.Sprintf("+ %s via %s\n", routeString(&route), route.NextHop))
+	}
+	for _, route := range diff.Removed {
+		b.WriteString(fmt.Sprintf("- %s via %s\n", routeString(&route), route.NextHop))
+	}
+	for _, change := range diff.Modified {
+		b.WriteString(fmt.Sprintf("~ %s via %s: %s\n", routeString(&change.New), change.New.NextHop,
+			strings.Join(change.Changes, ", ")))
+	}
+
+	return b.String()
+}
+
+// routeKey returns the key matching routes of two tables
+func routeKey(route *Route) string {
+	return fmt.Sprintf("%s|%d", routePrefixKey(route), route.PathId)
+}
+
+// routePrefixKey returns the key of the route prefix, shared by all paths of the prefix
+func routePrefixKey(route *Route) string {
+	key := routeString(route)
+	if route.Evpn != nil {
+		key += fmt.Sprintf("|%d|%s|%d|%s", route.Evpn.RouteType, route.Evpn.Esi, route.Evpn.EthernetTag, route.Evpn.Mac)
+	}
+
+	return key
+}
+
+// routeString returns the prefix of the route, with route distinguisher for VPN routes
+func routeString(route *Route) string {
+	prefix := fmt.Sprintf("%s/%d", route.Network, route.PrefixLen)
+	if route.Rd != "" {
+		return route.Rd + ":" + prefix
+	}
+
+	return prefix
+}
+
+// routeChanges returns names of attributes that differ between two routes
+func routeChanges(oldRoute *Route, newRoute *Route) []string {
+	changes := []string{}
+	if oldRoute.NextHop != newRoute.NextHop {
+		changes = append(changes, "next hop")
+	}
+	if !equalUint32(oldRoute.Metric, newRoute.Metric) {
+		changes = append(changes, "med")
+	}
+	if !equalUint32(oldRoute.LocalPref, newRoute.LocalPref) {
+		changes = append(changes, "local pref")
+	}
+	// weight is not carried by route ranges, compared only if found in both tables
+	if oldRoute.Weight != nil && newRoute.Weight != nil && *oldRoute.Weight != *newRoute.Weight {
+		changes = append(changes, "weight")
+	}
+	if oldRoute.Origin != newRoute.Origin {
+		changes = append(changes, "origin")
+	}
+	if !equalAsPath(oldRoute.AsPath, newRoute.AsPath) {
+		changes = append(changes, "as path")
+	}
+	if strings.Join(oldRoute.Communities, " ") != strings.Join(newRoute.Communities, " ") {
+		changes = append(changes, "communities")
+	}
+	if strings.Join(oldRoute.RouteTargets, " ") != strings.Join(newRoute.RouteTargets, " ") {
+		changes = append(changes, "route targets")
+	}
+	if !equalUint32(oldRoute.OutLabel, newRoute.OutLabel) {
+		changes = append(changes, "label")
+	}
+	if oldRoute.Evpn != nil && newRoute.Evpn != nil && !reflect.DeepEqual(oldRoute.Evpn.Labels, newRoute.Evpn.Labels) {
+		changes = append(changes, "evpn labels")
+	}
+
+	return changes
+}
+
+// equalAsPath compares AS paths segment by segment, nil and empty paths or segments are equal
+func equalAsPath(a []AsPathSegment, b []AsPathSegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || len(a[i].AsNumbers) != len(b[i].AsNumbers) {
+			return false
+		}
+		for j := range a[i].AsNumbers {
+			if a[i].AsNumbers[j] != b[i].AsNumbers[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func equalUint32(a *uint32, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
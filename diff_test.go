@@ -0,0 +1,102 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestDiffTables(t *testing.T) {
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "diff",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+	}
+
+	tables := []*[]routeimporter.Route{}
+	for _, filename := range []string{"resource/cisco_v4_basic.txt", "resource/cisco_v4_basic_changed.txt"} {
+		fb, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+			return
+		}
+		routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+			return
+		}
+		tables = append(tables, routes)
+	}
+
+	diff, err := routeimporter.DiffTables(tables[0], tables[1])
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not diff tables. error: %v", err))
+		return
+	}
+	fmt.Print(diff.String())
+
+	if len(diff.Added) != 1 || diff.Added[0].Network.String() != "1.0.6.0" {
+		t.Errorf("Expected added route 1.0.6.0/24, found %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Network.String() != "1.0.5.0" ||
+		diff.Removed[0].NextHop != "67.16.148.40" {
+		t.Errorf("Expected removed route 1.0.5.0/24 via 67.16.148.40, found %v", diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].New.Network.String() != "1.0.0.0" ||
+		!reflect.DeepEqual(diff.Modified[0].Changes, []string{"local pref"}) {
+		t.Errorf("Expected local pref change of 1.0.0.0/24, found %v", diff.Modified)
+	}
+	if diff.Unchanged != 4 {
+		t.Errorf("Expected 4 unchanged routes, found %d", diff.Unchanged)
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peer}
+	names, err := diff.ImportChanges(ic)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import changed routes. error: %v", err))
+		return
+	}
+	if len(*names) != 2 || len(peer.V4Routes().Items()) != 2 {
+		t.Errorf("Expected 2 changed route ranges, found %v", *names)
+	}
+}
+
+func TestDiffTablesAsPath(t *testing.T) {
+	oldTable := []routeimporter.Route{
+		{Network: net.ParseIP("10.0.0.0"), PrefixLen: 8, NextHop: "1.1.1.1", Rd: "100:1"},
+		{Network: net.ParseIP("11.0.0.0"), PrefixLen: 8, NextHop: "1.1.1.1", AsPath: []routeimporter.AsPathSegment{
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{65001}}}},
+	}
+	newTable := []routeimporter.Route{
+		{Network: net.ParseIP("10.0.0.0"), PrefixLen: 8, NextHop: "1.1.1.1", Rd: "100:1",
+			AsPath: []routeimporter.AsPathSegment{}},
+		{Network: net.ParseIP("11.0.0.0"), PrefixLen: 8, NextHop: "1.1.1.1", AsPath: []routeimporter.AsPathSegment{
+			{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{65001}}}},
+	}
+
+	diff, err := routeimporter.DiffTables(&oldTable, &newTable)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not diff tables. error: %v", err))
+		return
+	}
+	if diff.Unchanged != 1 || len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("Expected empty AS path of 100:1:10.0.0.0/8 unchanged, found %s", diff.String())
+	}
+	if len(diff.Modified) != 1 || !reflect.DeepEqual(diff.Modified[0].Changes, []string{"as path"}) {
+		t.Errorf("Expected as path change of 11.0.0.0/8, found %v", diff.Modified)
+	}
+}
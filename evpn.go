@@ -0,0 +1,756 @@
+package routeimporter
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	EVPN_HEADER_LOC_PREF = "LocPref" // Arista spelling of LocPrf
+
+	EVPN_INLINE_RD_PREFIX = "RD:" // Arista route distinguisher within network column
+	EVPN_RT_PREFIX        = "RT:"
+	EVPN_LABEL_PREFIX     = "Label:"
+	EVPN_VNI_PREFIX       = "VNI:"
+	EVPN_ABSENT_VALUE     = "-"
+
+	EVPN_ROUTE_TYPE_MAC_IP    = 2
+	EVPN_ROUTE_TYPE_IMET      = 3
+	EVPN_ROUTE_TYPE_IP_PREFIX = 5
+
+	EVPN_ZERO_ESI = "00000000000000000000"
+)
+
+var evpnNlriField = regexp.MustCompile(`\[([^\]]*)\]`)
+
+var aristaEvpnRouteTypes = map[string]int{
+	"mac-ip":    EVPN_ROUTE_TYPE_MAC_IP,
+	"imet":      EVPN_ROUTE_TYPE_IMET,
+	"ip-prefix": EVPN_ROUTE_TYPE_IP_PREFIX,
+}
+
+type evpnEntry struct {
+	Nlri     string
+	Row      int
+	Best     bool
+	Internal bool
+	Rd       string
+	Vrf      string
+	Route    *Route
+	Err      *error
+}
+
+// EvpnImporter imports EVPN routes from show bgp l2vpn evpn output.
+// Cisco / FRR bracketed NLRI (e.g. [2]:[0]:[0]:[48]:[0050.7966.6800]:[32]:[10.1.1.1])
+// as well as Arista (e.g. RD: 10.0.0.1:1 mac-ip 0050.7966.6800 10.1.1.1) formats are supported.
+type EvpnImporter struct {
+	id uint64
+
+	//
+	POS_HEADER_NEXT_HOP int
+	POS_HEADER_METRIC   int
+	POS_HEADER_LOC_PRF  int
+	POS_HEADER_WEIGHT   int
+	POS_HEADER_PATH     int
+
+	validRoutes int
+	startTask   time.Time
+	lines       []string
+	PeerV4      gosnappi.BgpV4Peer
+}
+
+// String returns the id of the client.
+func (imp *EvpnImporter) String() string {
+	return fmt.Sprintf("EVPN Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for an EVPN address family header or EVPN route NLRIs of show bgp l2vpn evpn output
+func (imp *EvpnImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		if strings.Contains(strings.ToLower(line), "evpn") {
+			return true
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), string(CISCO_VALID_ROUTE)) &&
+			(strings.Contains(line, "]:[") || strings.Contains(line, EVPN_INLINE_RD_PREFIX)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportRoutes adds EVPN routes to the target v4 peer, as MAC/IP ranges within the Ethernet
+// segments, EVIs and broadcast domains of the routes, reusing those already on the peer.
+// MAC/IP ranges are no route ranges, so merge modes matching existing routes, route groups
+// and SplitInternal are rejected.
+func (imp *EvpnImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	if err := checkEvpnImportConfig(&ic); err != nil {
+		return nil, err
+	}
+	if len(ic.Targetv4Peers) > 0 {
+		if len(ic.Targetv4Peers) > 1 {
+			// To be handled in future
+			return nil, fmt.Errorf("multiple target v4 peers currently not supported")
+		}
+		imp.PeerV4 = ic.Targetv4Peers[0]
+	} else {
+		return nil, fmt.Errorf("cannot import, no target v4 peers found")
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	report := &ImportReport{}
+	if ic.MergeMode == MergeModeReplace {
+		report.Removed = evpnCmacRanges(imp.PeerV4)
+		imp.PeerV4.EvpnEthernetSegments().Clear()
+	}
+	setAsNumberWidth(&ic, imp.PeerV4, nil)
+	segments, evis, domains, existing := existingEvpnConstructs(imp.PeerV4)
+	names := newNameSet(existing)
+	for i := range *routes {
+		route := &(*routes)[i]
+		if route.Evpn.RouteType == EVPN_ROUTE_TYPE_IP_PREFIX {
+			// no IP prefix route construct in gosnappi
+			log.Info().Msgf("EVPN type 5 route %s/%d not supported for peer update (line %d)",
+				route.Network, route.PrefixLen, route.Row+1)
+			continue
+		}
+
+		rdType, err := routeDistinguisherType(route.Rd)
+		if err != nil && route.Rd != "" {
+			log.Info().Msgf("%v (line %d)", err, route.Row+1)
+			continue
+		}
+		segment, ok := segments[route.Evpn.Esi]
+		if !ok {
+			segment = imp.PeerV4.EvpnEthernetSegments().Add().SetEsi(route.Evpn.Esi)
+			segments[route.Evpn.Esi] = segment
+		}
+		eviKey := route.Evpn.Esi + "/" + route.Rd
+		evi, ok := evis[eviKey]
+		if !ok {
+			evi = segment.Evis().Add().EviVxlan()
+			if route.Rd != "" {
+				evi.RouteDistinguisher().SetRdType(rdType).SetRdValue(route.Rd)
+			}
+			evis[eviKey] = evi
+		}
+		if err := addEviRouteTargets(evi, route.RouteTargets); err != nil {
+			log.Info().Msgf("%v (line %d)", err, route.Row+1)
+			continue
+		}
+		domainKey := fmt.Sprintf("%s/%d", eviKey, route.Evpn.EthernetTag)
+		domain, ok := domains[domainKey]
+		if !ok {
+			domain = evi.BroadcastDomains().Add().SetEthernetTagId(route.Evpn.EthernetTag)
+			domains[domainKey] = domain
+		}
+
+		if route.Evpn.RouteType == EVPN_ROUTE_TYPE_IMET {
+			if len(route.Evpn.Labels) > 0 {
+				evi.SetPmsiLabel(route.Evpn.Labels[0])
+			}
+			imp.validRoutes++
+			continue
+		}
+
+		name := names.unique(route.Name)
+		if name != route.Name {
+			report.Renamed++
+		}
+		cmac := domain.CmacIpRange().Add().SetName(name)
+		cmac.MacAddresses().SetAddress(route.Evpn.Mac).SetPrefix(48).SetCount(1)
+		if route.Network != nil {
+			if route.Network.To4() != nil {
+				cmac.Ipv4Addresses().SetAddress(route.Network.String()).SetPrefix(32).SetCount(1)
+			} else {
+				cmac.Ipv6Addresses().SetAddress(route.Network.String()).SetPrefix(128).SetCount(1)
+			}
+		}
+		if len(route.Evpn.Labels) > 0 {
+			cmac.SetL2Vni(route.Evpn.Labels[0])
+		}
+		if len(route.Evpn.Labels) > 1 {
+			cmac.SetL3Vni(route.Evpn.Labels[1])
+		}
+		if route.LocalPref != nil {
+			cmac.Advanced().SetIncludeLocalPreference(true)
+			cmac.Advanced().SetLocalPreference(*route.LocalPref)
+		}
+		if route.Metric != nil {
+			cmac.Advanced().SetIncludeMultiExitDiscriminator(true)
+			cmac.Advanced().SetMultiExitDiscriminator(*route.Metric)
+		}
+		cmac.Advanced().SetIncludeOrigin(true)
+		cmac.Advanced().SetOrigin(route.Origin)
+		if asPath := routeForAsWidth(route, &ic).AsPath; len(asPath) > 0 {
+			if imp.PeerV4.AsType() == gosnappi.BgpV4PeerAsType.EBGP {
+				cmac.AsPath().SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ)
+			}
+			for _, seg := range asPath {
+				cmac.AsPath().Segments().Add().SetType(seg.Type).SetAsNumbers(seg.AsNumbers)
+			}
+		}
+		route_names = append(route_names, name)
+		report.Added++
+		imp.validRoutes++
+	}
+	if len(segments) > 0 {
+		imp.PeerV4.Capability().SetEvpn(true)
+	}
+	if ic.Report != nil {
+		// routes filtered while parsing are kept
+		report.Filtered, report.AsTrans = ic.Report.Filtered, ic.Report.AsTrans
+		report.ScaleSkipped = ic.Report.ScaleSkipped
+		*ic.Report = *report
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// checkEvpnImportConfig rejects import options EVPN routes cannot be merged with
+func checkEvpnImportConfig(ic *ImportConfig) error {
+	switch {
+	case ic.MergeMode == MergeModeUpsert || ic.MergeMode == MergeModeSkip:
+		return fmt.Errorf("cannot import - upsert and skip merge modes not supported for EVPN routes")
+	case ic.RouteGroups != RouteGroupNone:
+		return fmt.Errorf("cannot import - route groups not supported for EVPN routes")
+	case ic.SplitInternal:
+		return fmt.Errorf("cannot import - split internal not supported for EVPN routes")
+	}
+
+	return nil
+}
+
+// existingEvpnConstructs indexes Ethernet segments per ESI, VXLAN EVIs per ESI and route
+// distinguisher and broadcast domains per EVI and Ethernet tag of the peer, and returns the
+// names of its route ranges and MAC/IP ranges
+func existingEvpnConstructs(peer gosnappi.BgpV4Peer) (map[string]gosnappi.BgpV4EthernetSegment,
+	map[string]gosnappi.BgpV4EviVxlan, map[string]gosnappi.BgpV4EviVxlanBroadcastDomain, []string) {
+	segments := map[string]gosnappi.BgpV4EthernetSegment{}
+	evis := map[string]gosnappi.BgpV4EviVxlan{}
+	domains := map[string]gosnappi.BgpV4EviVxlanBroadcastDomain{}
+	names := []string{}
+	for _, rr := range peer.V4Routes().Items() {
+		names = append(names, rr.Name())
+	}
+	for _, rr := range peer.V6Routes().Items() {
+		names = append(names, rr.Name())
+	}
+	for _, segment := range peer.EvpnEthernetSegments().Items() {
+		if _, ok := segments[segment.Esi()]; !ok {
+			segments[segment.Esi()] = segment
+		}
+		for _, item := range segment.Evis().Items() {
+			if !item.HasEviVxlan() {
+				continue
+			}
+			evi := item.EviVxlan()
+			rd := ""
+			if evi.HasRouteDistinguisher() {
+				rd = evi.RouteDistinguisher().RdValue()
+			}
+			eviKey := segment.Esi() + "/" + rd
+			if _, ok := evis[eviKey]; !ok {
+				evis[eviKey] = evi
+			}
+			for _, domain := range evi.BroadcastDomains().Items() {
+				domainKey := fmt.Sprintf("%s/%d", eviKey, domain.EthernetTagId())
+				if _, ok := domains[domainKey]; !ok {
+					domains[domainKey] = domain
+				}
+				for _, cmac := range domain.CmacIpRange().Items() {
+					names = append(names, cmac.Name())
+				}
+			}
+		}
+	}
+
+	return segments, evis, domains, names
+}
+
+// evpnCmacRanges returns the count of MAC/IP ranges of the peer
+func evpnCmacRanges(peer gosnappi.BgpV4Peer) int {
+	count := 0
+	for _, segment := range peer.EvpnEthernetSegments().Items() {
+		for _, item := range segment.Evis().Items() {
+			if !item.HasEviVxlan() {
+				continue
+			}
+			for _, domain := range item.EviVxlan().BroadcastDomains().Items() {
+				count += len(domain.CmacIpRange().Items())
+			}
+		}
+	}
+
+	return count
+}
+
+// ParseRoutes parses valid EVPN routes from the buffer without updating any target peer
+func (imp *EvpnImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	imp.lines = strings.Split(string(*buffer), "\n")
+	var next int = 0
+	var err error = nil
+	if next, err = imp.TryParseHeader(); err != nil {
+		return nil, fmt.Errorf("cannot import, header not found - %v", err.Error())
+	}
+
+	var rd, vrf string
+	entries := []evpnEntry{}
+	for index := next; index < len(imp.lines); index++ {
+		line := strings.TrimRight(imp.lines[index], "\r")
+		if strings.HasPrefix(line, CISCO_RD_PREFIX) {
+			rd, vrf = parseRdLine(line, "")
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, string(CISCO_VALID_ROUTE)) {
+			continue
+		}
+		start := strings.IndexAny(line, "[")
+		if inline := strings.Index(line, EVPN_INLINE_RD_PREFIX); inline != -1 && (start == -1 || inline < start) {
+			start = inline
+		}
+		if start == -1 {
+			continue
+		}
+		best := strings.ContainsRune(line[:start], CISCO_BEST_ROUTE)
+		if ic.BestRoutes && !best {
+			continue
+		}
+		internal := strings.ContainsRune(line[:start], CISCO_INTERNAL_ROUTE)
+		entries = append(entries, evpnEntry{Nlri: line[start:], Row: index, Best: best, Internal: internal, Rd: rd, Vrf: vrf})
+	}
+	if ic.SequentialProcess {
+		for i := range entries {
+			imp.ProcessEntry(&entries[i], &ic)
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i := range entries {
+			wg.Add(1)
+			go func(entry *evpnEntry) {
+				defer wg.Done()
+				imp.ProcessEntry(entry, &ic)
+			}(&entries[i])
+		}
+		wg.Wait()
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+
+	routes := []Route{}
+	for _, entry := range entries {
+		if entry.Route != nil {
+			if isSelectedVrf(&ic, entry.Route.Rd, entry.Route.Vrf) {
+				routes = append(routes, *entry.Route)
+			}
+		} else if entry.Err != nil {
+			log.Info().Msgf((*entry.Err).Error())
+		}
+	}
+	routes, err = processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+func (imp *EvpnImporter) TryParseHeader() (int, error) {
+	for index, line := range imp.lines {
+		if strings.Contains(line, CISCO_HEADER_NETWORK) && strings.Contains(line, CISCO_HEADER_NEXT_HOP) {
+			if err := imp.GetHeaderPositions(line); err != nil {
+				log.Info().Msgf("%v", err)
+			} else {
+				return index + 1, nil
+			}
+		}
+	}
+
+	return -1, fmt.Errorf("invalid format - failed to locate header")
+}
+
+func (imp *EvpnImporter) GetHeaderPositions(line string) error {
+	positions := []*int{
+		&imp.POS_HEADER_NEXT_HOP,
+		&imp.POS_HEADER_METRIC,
+		&imp.POS_HEADER_LOC_PRF,
+		&imp.POS_HEADER_WEIGHT,
+		&imp.POS_HEADER_PATH,
+	}
+	headers := [][]string{
+		{CISCO_HEADER_NEXT_HOP},
+		{CISCO_HEADER_METRIC},
+		{CISCO_HEADER_LOC_PRF, EVPN_HEADER_LOC_PREF},
+		{CISCO_HEADER_WEIGHT},
+		{CISCO_HEADER_PATH},
+	}
+	offset := strings.Index(line, CISCO_HEADER_NETWORK) + len(CISCO_HEADER_NETWORK)
+	for i, names := range headers {
+		pos := -1
+		for _, name := range names {
+			if pos = strings.Index(line[offset:], name); pos != -1 {
+				*positions[i] = pos + offset
+				offset = pos + offset + len(name)
+				break
+			}
+		}
+		if pos == -1 {
+			return fmt.Errorf("Invalid header format - missing %s", names[0])
+		}
+	}
+
+	return nil
+}
+
+// ProcessEntry parses NLRI and attributes of an EVPN route entry
+func (imp *EvpnImporter) ProcessEntry(entry *evpnEntry, ic *ImportConfig) {
+	var err error
+	var route *Route
+	nlri := strings.TrimRight(entry.Nlri, "\r")
+	attrRow := entry.Row
+	if strings.HasPrefix(nlri, EVPN_INLINE_RD_PREFIX) {
+		route, err = parseAristaEvpnNlri(nlri)
+		attrRow++
+	} else {
+		if offset := strings.Index(nlri, " "); offset != -1 {
+			nlri = nlri[:offset]
+		}
+		route, err = parseEvpnNlri(nlri)
+		if route != nil && route.Rd == "" {
+			route.Rd = entry.Rd
+		}
+		// attributes continue on the same line only if NLRI fits in the network column
+		if len(imp.lines[entry.Row]) <= imp.POS_HEADER_NEXT_HOP ||
+			strings.Index(imp.lines[entry.Row], nlri)+len(nlri) >= imp.POS_HEADER_NEXT_HOP {
+			attrRow++
+		}
+	}
+	if err != nil {
+		pErr := fmt.Errorf("Row: %d, EVPN NLRI parsing error: %s", entry.Row+1, err.Error())
+		entry.Err = &pErr
+		return
+	}
+	if attrRow >= len(imp.lines) {
+		pErr := fmt.Errorf("no attributes found (line %d)", entry.Row+1)
+		entry.Err = &pErr
+		return
+	}
+	route.Row = entry.Row
+	route.Best = entry.Best
+	route.Internal = entry.Internal
+	route.Vrf = entry.Vrf
+
+	line := strings.TrimRight(imp.lines[attrRow], "\r")
+	route.NextHop = evpnColumn(line, imp.POS_HEADER_NEXT_HOP, imp.POS_HEADER_METRIC)
+	metric := evpnColumn(line, imp.POS_HEADER_METRIC, imp.POS_HEADER_LOC_PRF)
+	locPrf := evpnColumn(line, imp.POS_HEADER_LOC_PRF, imp.POS_HEADER_WEIGHT)
+	weight := evpnColumn(line, imp.POS_HEADER_WEIGHT, imp.POS_HEADER_PATH)
+	path := evpnColumn(line, imp.POS_HEADER_PATH, len(line))
+	if ic.RetainNexthop && route.NextHop == "" {
+		pErr := fmt.Errorf("no nexthop found (line %d)", attrRow+1)
+		entry.Err = &pErr
+		return
+	}
+	if route.LocalPref, err = parseLocalPrf(locPrf, attrRow); err == nil {
+		if route.Metric, err = parseMetric(metric, attrRow); err == nil {
+			if route.Weight, err = parseWeight(weight, attrRow); err == nil {
+				if len(path) > 0 {
+					if err, route.Origin = getOriginValue(path[len(path)-1:]); err == nil {
+						route.AsPath, err = parseAsPath(path, attrRow)
+					}
+				} else {
+					err = fmt.Errorf("found path parameter to be empty (line %d)", attrRow+1)
+				}
+			}
+		}
+	}
+	if err != nil {
+		entry.Err = &err
+		return
+	}
+
+	// extended community / label lines following the route
+	for row := attrRow + 1; row < len(imp.lines); row++ {
+		trimmed := strings.TrimSpace(imp.lines[row])
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, string(CISCO_VALID_ROUTE)) ||
+			strings.HasPrefix(trimmed, CISCO_RD_PREFIX) {
+			break
+		}
+		for _, token := range strings.Fields(trimmed) {
+			switch {
+			case strings.HasPrefix(token, EVPN_RT_PREFIX):
+				route.RouteTargets = append(route.RouteTargets, token[len(EVPN_RT_PREFIX):])
+			case strings.HasPrefix(token, EVPN_LABEL_PREFIX), strings.HasPrefix(token, EVPN_VNI_PREFIX):
+				value := token[strings.Index(token, ":")+1:]
+				if label, err := strconv.ParseUint(value, 10, 32); err == nil {
+					route.Evpn.Labels = append(route.Evpn.Labels, uint32(label))
+				}
+			}
+		}
+	}
+	entry.Route = route
+}
+
+// evpnColumn returns trimmed column value, with absent ("-") values returned as empty
+func evpnColumn(line string, pos int, next int) string {
+	if len(line) <= pos {
+		return ""
+	}
+	if len(line) < next {
+		next = len(line)
+	}
+	value := strings.TrimSpace(line[pos:next])
+	if value == EVPN_ABSENT_VALUE {
+		return ""
+	}
+
+	return value
+}
+
+// parseEvpnNlri parses bracketed NLRI, e.g.
+// NX-OS  [2]:[0]:[0]:[48]:[0050.7966.6800]:[32]:[10.1.1.1]/272
+// IOS-XE [2][65000:1][0][48][0050.7966.6800][32][10.1.1.1]/24
+// FRR    [2]:[0]:[48]:[00:50:79:66:68:00]:[32]:[10.1.1.1]
+func parseEvpnNlri(nlri string) (*Route, error) {
+	matches := evpnNlriField.FindAllStringSubmatch(nlri, -1)
+	fields := []string{}
+	for _, match := range matches {
+		fields = append(fields, match[1])
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid EVPN NLRI %q", nlri)
+	}
+	routeType, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid EVPN route type %q", fields[0])
+	}
+
+	route := &Route{Evpn: &EvpnRoute{RouteType: routeType, Esi: EVPN_ZERO_ESI}}
+	var tagIndex int
+	switch routeType {
+	case EVPN_ROUTE_TYPE_MAC_IP:
+		macIndex := -1
+		for i := 2; i < len(fields); i++ {
+			if isMacAddress(fields[i]) {
+				macIndex = i
+				break
+			}
+		}
+		if macIndex < 3 {
+			return nil, fmt.Errorf("missing MAC address in EVPN NLRI %q", nlri)
+		}
+		if route.Evpn.Mac, err = normalizeMac(fields[macIndex]); err != nil {
+			return nil, err
+		}
+		if macIndex+2 < len(fields) {
+			if err = setEvpnAddress(route, fields[macIndex+1], fields[macIndex+2]); err != nil {
+				return nil, err
+			}
+		}
+		tagIndex = macIndex - 2
+	case EVPN_ROUTE_TYPE_IMET, EVPN_ROUTE_TYPE_IP_PREFIX:
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("incomplete EVPN NLRI %q", nlri)
+		}
+		last := len(fields) - 1
+		if err = setEvpnAddress(route, fields[last-1], fields[last]); err != nil {
+			return nil, err
+		}
+		tagIndex = last - 2
+	default:
+		return nil, fmt.Errorf("EVPN route type %d not supported", routeType)
+	}
+
+	tag, err := strconv.ParseUint(fields[tagIndex], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ethernet tag %q in EVPN NLRI", fields[tagIndex])
+	}
+	route.Evpn.EthernetTag = uint32(tag)
+	// fields between route type and ethernet tag are route distinguisher or ESI
+	for _, field := range fields[1:tagIndex] {
+		if strings.Count(field, ":") == 1 {
+			route.Rd = field
+		} else if route.Evpn.Esi, err = normalizeEsi(field); err != nil {
+			return nil, err
+		}
+	}
+
+	return route, nil
+}
+
+// parseAristaEvpnNlri parses Arista NLRI, e.g.
+// RD: 10.0.0.1:10010 mac-ip [tag] 0050.7966.6800 [10.1.1.1]
+// RD: 10.0.0.1:10010 imet [tag] 10.0.0.1
+// RD: 10.0.0.1:1 ip-prefix 10.1.1.0/24
+func parseAristaEvpnNlri(nlri string) (*Route, error) {
+	tokens := strings.Fields(nlri)
+	if len(tokens) < 4 {
+		return nil, fmt.Errorf("incomplete EVPN NLRI %q", nlri)
+	}
+	routeType, ok := aristaEvpnRouteTypes[tokens[2]]
+	if !ok {
+		return nil, fmt.Errorf("EVPN route type %q not supported", tokens[2])
+	}
+	route := &Route{Rd: tokens[1], Evpn: &EvpnRoute{RouteType: routeType, Esi: EVPN_ZERO_ESI}}
+	args := tokens[3:]
+	if tag, err := strconv.ParseUint(args[0], 10, 32); err == nil && len(args) > 1 {
+		route.Evpn.EthernetTag = uint32(tag)
+		args = args[1:]
+	}
+
+	var err error
+	switch routeType {
+	case EVPN_ROUTE_TYPE_MAC_IP:
+		if route.Evpn.Mac, err = normalizeMac(args[0]); err != nil {
+			return nil, err
+		}
+		if len(args) > 1 {
+			err = setEvpnAddress(route, "", args[1])
+		}
+	case EVPN_ROUTE_TYPE_IMET:
+		err = setEvpnAddress(route, "", args[0])
+	case EVPN_ROUTE_TYPE_IP_PREFIX:
+		var ip net.IP
+		if ip, route.PrefixLen, err = ParseNetworkAddress(args[0]); err == nil {
+			route.Network = ip
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// setEvpnAddress sets route network from IP length and address NLRI fields
+func setEvpnAddress(route *Route, length string, address string) error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return fmt.Errorf("not valid ip address : %q", address)
+	}
+	if ip.IsUnspecified() {
+		return nil
+	}
+	route.Network = ip
+	route.PrefixLen = 8 * len(ip)
+	if ip.To4() != nil {
+		route.PrefixLen = 32
+	}
+	if length != "" {
+		mask, err := strconv.Atoi(length)
+		if err != nil {
+			return fmt.Errorf("invalid IP length %q in EVPN NLRI", length)
+		}
+		if route.Evpn.RouteType == EVPN_ROUTE_TYPE_IP_PREFIX {
+			route.PrefixLen = mask
+		}
+	}
+
+	return nil
+}
+
+func isMacAddress(token string) bool {
+	_, err := normalizeMac(token)
+	return err == nil
+}
+
+// normalizeMac converts dotted (0050.7966.6800) or colon separated MAC into
+// colon separated lower case format
+func normalizeMac(token string) (string, error) {
+	hw, err := net.ParseMAC(token)
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("invalid MAC address %q", token)
+	}
+
+	return hw.String(), nil
+}
+
+// normalizeEsi converts ESI notation (0, 0000.0000.0000.0000.0000,
+// 00:00:00:00:00:00:00:00:00:00) into 10 octet hex string
+func normalizeEsi(token string) (string, error) {
+	if token == "0" {
+		return EVPN_ZERO_ESI, nil
+	}
+	esi := strings.ToLower(strings.NewReplacer(".", "", ":", "").Replace(token))
+	if len(esi) != len(EVPN_ZERO_ESI) {
+		return "", fmt.Errorf("invalid ESI %q", token)
+	}
+	if _, err := strconv.ParseUint(esi[:10], 16, 64); err != nil {
+		return "", fmt.Errorf("invalid ESI %q", token)
+	}
+	if _, err := strconv.ParseUint(esi[10:], 16, 64); err != nil {
+		return "", fmt.Errorf("invalid ESI %q", token)
+	}
+
+	return esi, nil
+}
+
+// addEviRouteTargets adds route targets not yet present as export route targets of the EVI
+func addEviRouteTargets(evi gosnappi.BgpV4EviVxlan, rts []string) error {
+	for _, rt := range rts {
+		found := false
+		for _, existing := range evi.RouteTargetExport().Items() {
+			if existing.RtValue() == rt {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		extType, _, err := routeTargetValue(rt)
+		if err != nil {
+			return err
+		}
+		rtType := gosnappi.BgpRouteTargetRtType.AS_2OCTET
+		switch extType {
+		case gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS:
+			rtType = gosnappi.BgpRouteTargetRtType.IPV4_ADDRESS
+		case gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET:
+			rtType = gosnappi.BgpRouteTargetRtType.AS_4OCTET
+		}
+		evi.RouteTargetExport().Add().SetRtType(rtType).SetRtValue(rt)
+	}
+
+	return nil
+}
+
+// routeDistinguisherType returns gosnappi RD type for route distinguisher notation
+func routeDistinguisherType(rd string) (gosnappi.BgpRouteDistinguisherRdTypeEnum, error) {
+	extType, _, err := routeTargetValue(rd)
+	if err != nil {
+		return gosnappi.BgpRouteDistinguisherRdType.AS_2OCTET, err
+	}
+	switch extType {
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS:
+		return gosnappi.BgpRouteDistinguisherRdType.IPV4_ADDRESS, nil
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET:
+		return gosnappi.BgpRouteDistinguisherRdType.AS_4OCTET, nil
+	}
+
+	return gosnappi.BgpRouteDistinguisherRdType.AS_2OCTET, nil
+}
@@ -0,0 +1,214 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type EvpnEntry struct {
+	RouteType   int
+	Rd          string
+	Esi         string
+	EthernetTag uint32
+	Mac         string
+	Network     string
+}
+
+func TestImportEvpnRoutesNxos(t *testing.T) {
+	filename := "resource/nxos_evpn_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeEvpn)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "evpnImp",
+		BestRoutes:    true,
+		RetainNexthop: true,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	expRoutes := []EvpnEntry{
+		{RouteType: 2, Rd: "10.0.0.1:32777", Esi: "00000000000000000000", Mac: "00:50:79:66:68:00", Network: "<nil>"},
+		{RouteType: 2, Rd: "10.0.0.1:32777", Esi: "00000000000000000000", Mac: "00:50:79:66:68:01", Network: "192.168.10.11"},
+		{RouteType: 3, Rd: "10.0.0.1:32777", Esi: "00000000000000000000", Network: "10.0.0.1"},
+		{RouteType: 5, Rd: "10.0.0.2:3", Esi: "00000000000000000000", Network: "192.168.20.0"},
+		{RouteType: 2, Rd: "10.0.0.2:3", Esi: "03aabbccdd0000000001", EthernetTag: 100, Mac: "00:50:79:66:69:00", Network: "<nil>"},
+	}
+	validateEvpnRoutes(t, routes, expRoutes)
+
+	route := (*routes)[1]
+	if len(route.RouteTargets) != 1 || route.RouteTargets[0] != "65001:10100" ||
+		len(route.Evpn.Labels) != 2 || route.Evpn.Labels[1] != 50001 {
+		t.Errorf("Route targets / labels mismatch. Found: %v %v", route.RouteTargets, route.Evpn.Labels)
+	}
+	if route.LocalPref == nil || *route.LocalPref != 100 || len(route.AsPath) != 1 {
+		t.Errorf("Route attributes mismatch for %s", route.Name)
+	}
+	if (*routes)[3].PrefixLen != 24 {
+		t.Errorf("Expected type 5 prefix length 24, found %d", (*routes)[3].PrefixLen)
+	}
+
+	peer := gosnappi.NewBgpV4Peer().SetName("evpnPeer").SetPeerAddress("10.0.0.9").
+		SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peer}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 3 {
+		t.Errorf("Expected MAC/IP Route Count: 3, Imported Routes Count: %d", len(*names))
+	}
+	segments := peer.EvpnEthernetSegments().Items()
+	if len(segments) != 2 {
+		t.Errorf("Expected Ethernet Segment Count: 2, Found: %d", len(segments))
+		return
+	}
+	evi := segments[0].Evis().Items()[0].EviVxlan()
+	if evi.RouteDistinguisher().RdValue() != "10.0.0.1:32777" || len(evi.RouteTargetExport().Items()) != 1 {
+		t.Errorf("EVI mismatch. Found: %v", evi)
+	}
+	cmacs := evi.BroadcastDomains().Items()[0].CmacIpRange().Items()
+	if len(cmacs) != 2 || cmacs[1].Ipv4Addresses().Address() != "192.168.10.11" || cmacs[1].L3Vni() != 50001 {
+		t.Errorf("MAC/IP range mismatch. Found: %v", cmacs)
+	}
+	if err := peer.Validate(); err != nil {
+		t.Errorf("Invalid peer after import. Error: %v", err)
+	}
+}
+
+func TestImportEvpnRoutesArista(t *testing.T) {
+	filename := "resource/arista_evpn_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeEvpn)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "evpnImp",
+		BestRoutes: true,
+		Vrfs:       []string{"10.0.0.2:10010", "10.0.0.2:1"},
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	expRoutes := []EvpnEntry{
+		{RouteType: 2, Rd: "10.0.0.2:10010", Esi: "00000000000000000000", EthernetTag: 10010, Mac: "00:50:79:66:68:01", Network: "192.168.10.11"},
+		{RouteType: 5, Rd: "10.0.0.2:1", Esi: "00000000000000000000", Network: "192.168.20.0"},
+	}
+	validateEvpnRoutes(t, routes, expRoutes)
+	if len(*routes) > 0 && ((*routes)[0].NextHop != "10.0.0.2" || (*routes)[0].Metric != nil) {
+		t.Errorf("Route attributes mismatch for %s", (*routes)[0].Name)
+	}
+}
+
+func validateEvpnRoutes(t *testing.T, routes *[]routeimporter.Route, expRoutes []EvpnEntry) {
+	if len(*routes) != len(expRoutes) {
+		t.Errorf("Expected Route Count: %d, Parsed Routes Count: %d", len(expRoutes), len(*routes))
+		return
+	}
+	for i, exp := range expRoutes {
+		route := (*routes)[i]
+		found := EvpnEntry{
+			RouteType:   route.Evpn.RouteType,
+			Rd:          route.Rd,
+			Esi:         route.Evpn.Esi,
+			EthernetTag: route.Evpn.EthernetTag,
+			Mac:         route.Evpn.Mac,
+			Network:     route.Network.String(),
+		}
+		if found != exp {
+			t.Errorf("Route mismatch at %d. Expected: %v, Parsed: %v", i, exp, found)
+		}
+	}
+}
+
+func TestImportEvpnRoutesMerge(t *testing.T) {
+	filename := "resource/nxos_evpn_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeEvpn)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	peer := gosnappi.NewBgpV4Peer().SetName("evpnPeer").SetPeerAddress("10.0.0.9").
+		SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "evpnImp",
+		BestRoutes:    true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		Report:        &report,
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+
+	// second import reuses segments, EVIs and broadcast domains, MAC/IP ranges renamed
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	segments := peer.EvpnEthernetSegments().Items()
+	if len(segments) != 2 || len(segments[0].Evis().Items()) != 1 {
+		t.Errorf("Expected 2 Ethernet segments with 1 EVI, found %d", len(segments))
+		return
+	}
+	cmacs := segments[0].Evis().Items()[0].EviVxlan().BroadcastDomains().Items()[0].CmacIpRange().Items()
+	if len(cmacs) != 4 || (*names)[0] != cmacs[2].Name() || cmacs[2].Name() == cmacs[0].Name() {
+		t.Errorf("Expected 4 uniquely named MAC/IP ranges, found %d, names %v", len(cmacs), *names)
+	}
+	if report.Added != 3 || report.Renamed != 3 {
+		t.Errorf("Expected 3 added and renamed routes in report, found %d, %d", report.Added, report.Renamed)
+	}
+
+	ic.MergeMode = routeimporter.MergeModeReplace
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(peer.EvpnEthernetSegments().Items()) != 2 || report.Removed != 6 || report.Added != 3 {
+		t.Errorf("Expected 6 removed and 3 added routes in report, found %d, %d", report.Removed, report.Added)
+	}
+
+	for _, bad := range []routeimporter.ImportConfig{
+		{NamePrefix: "evpnImp", Targetv4Peers: []gosnappi.BgpV4Peer{peer}, MergeMode: routeimporter.MergeModeUpsert},
+		{NamePrefix: "evpnImp", Targetv4Peers: []gosnappi.BgpV4Peer{peer}, RouteGroups: routeimporter.RouteGroupAll},
+		{NamePrefix: "evpnImp", Targetv4Peers: []gosnappi.BgpV4Peer{peer}, SplitInternal: true},
+	} {
+		if _, err := is.ImportRoutes(bad, &fb); err == nil {
+			t.Errorf("Expected error importing EVPN routes with %+v", bad)
+		}
+	}
+}
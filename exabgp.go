@@ -0,0 +1,318 @@
+package routeimporter
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	EXABGP_ROUTE         = "route"
+	EXABGP_UNICAST       = "unicast"
+	EXABGP_WITHDRAW      = "withdraw"
+	EXABGP_NEIGHBOR      = "neighbor"
+	EXABGP_LOCAL_AS      = "local-as"
+	EXABGP_PEER_AS       = "peer-as"
+	EXABGP_NEXT_HOP      = "next-hop"
+	EXABGP_NEXT_HOP_SELF = "self"
+	EXABGP_AS_PATH       = "as-path"
+	EXABGP_ORIGIN        = "origin"
+	EXABGP_MED           = "med"
+	EXABGP_LOCAL_PREF    = "local-preference"
+	EXABGP_COMMUNITY     = "community"
+	EXABGP_PATH_INFO     = "path-information"
+	EXABGP_ATOMIC_AGGR   = "atomic-aggregate"
+	EXABGP_COMMENT       = "#"
+	EXABGP_END_OF_LINE   = "\n"
+)
+
+// exabgpFlags are route attributes without value
+var exabgpFlags = map[string]bool{
+	EXABGP_ATOMIC_AGGR: true,
+	EXABGP_WITHDRAW:    true,
+}
+
+// ExabgpImporter imports routes from ExaBGP configuration and API announce commands
+type ExabgpImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+}
+
+// exabgpToken holds a token of ExaBGP configuration and the row it is found in
+type exabgpToken struct {
+	Text string
+	Row  int
+}
+
+// exabgpNeighbor holds AS numbers of a neighbor block and the routes configured within
+type exabgpNeighbor struct {
+	Depth   int
+	LocalAs string
+	PeerAs  string
+	Routes  []int
+}
+
+// String returns the id of the client.
+func (imp *ExabgpImporter) String() string {
+	return fmt.Sprintf("ExaBGP Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for a neighbor block, or a route statement with next hop
+func (imp *ExabgpImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], EXABGP_COMMENT) {
+			continue
+		}
+		if fields[0] == EXABGP_NEIGHBOR && strings.HasSuffix(line, "{") {
+			return true
+		}
+		if (fields[0] == EXABGP_ROUTE || fields[0] == EXABGP_UNICAST || fields[0] == "announce") &&
+			strings.Contains(line, EXABGP_NEXT_HOP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportRoutes adds one route range per route statement, IPv4 routes to the target v4 peer
+// and IPv6 routes to the target v6 peer.
+func (imp *ExabgpImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses route statements of static / announce blocks, like
+// "route 10.0.0.0/24 next-hop 192.0.2.1 as-path [ 65001 65002 ];" or "unicast 10.0.0.0/24 { next-hop self; }",
+// and API commands like "announce route 10.0.0.0/24 next-hop self". Routes of neighbors with
+// same local and peer AS are internal, next hop self leaves the next hop unset. Withdraw commands,
+// statements flagged withdraw and statements with invalid attributes are skipped.
+func (imp *ExabgpImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	tokens := tokenizeExabgp(string(*buffer))
+	routes := []Route{}
+	neighbors := []*exabgpNeighbor{}
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i].Text
+		switch {
+		case token == "{":
+			depth++
+		case token == "}":
+			depth--
+			if n := len(neighbors); n > 0 && neighbors[n-1].Depth == depth {
+				neighbor := neighbors[n-1]
+				neighbors = neighbors[:n-1]
+				if neighbor.LocalAs != "" && neighbor.LocalAs == neighbor.PeerAs {
+					for _, r := range neighbor.Routes {
+						routes[r].Internal = true
+					}
+				}
+			}
+		case token == EXABGP_WITHDRAW:
+			// API withdraw command, not an announced route
+			_, next := exabgpStatement(tokens, i+1)
+			i = next - 1
+		case token == EXABGP_NEIGHBOR && i+2 < len(tokens) && tokens[i+2].Text == "{":
+			neighbors = append(neighbors, &exabgpNeighbor{Depth: depth})
+		case (token == EXABGP_LOCAL_AS || token == EXABGP_PEER_AS) && i+1 < len(tokens) && len(neighbors) > 0:
+			if token == EXABGP_LOCAL_AS {
+				neighbors[len(neighbors)-1].LocalAs = tokens[i+1].Text
+			} else {
+				neighbors[len(neighbors)-1].PeerAs = tokens[i+1].Text
+			}
+		case (token == EXABGP_ROUTE || token == EXABGP_UNICAST) && i+1 < len(tokens):
+			if _, _, err := parsePrefix(tokens[i+1].Text); err != nil {
+				// not a route statement, e.g. route refresh capability
+				continue
+			}
+			attrs, next := exabgpStatement(tokens, i+2)
+			route, err := parseExabgpRoute(tokens[i+1], attrs)
+			i = next - 1
+			if err != nil {
+				log.Info().Msgf(err.Error())
+				continue
+			}
+			if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+				(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+				continue
+			}
+			if len(neighbors) > 0 {
+				neighbor := neighbors[len(neighbors)-1]
+				neighbor.Routes = append(neighbor.Routes, len(routes))
+			}
+			routes = append(routes, *route)
+		}
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+
+	routes, err := processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// tokenizeExabgp splits configuration into tokens, braces, brackets, parentheses and
+// semicolons being tokens of their own, and line ends ending API commands
+func tokenizeExabgp(config string) []exabgpToken {
+	replacer := strings.NewReplacer("{", " { ", "}", " } ", "[", " [ ", "]", " ] ", "(", " ( ", ")", " ) ", ";", " ; ")
+	tokens := []exabgpToken{}
+	for row, line := range strings.Split(config, "\n") {
+		if comment := strings.Index(line, EXABGP_COMMENT); comment != -1 {
+			line = line[:comment]
+		}
+		for _, field := range strings.Fields(replacer.Replace(line)) {
+			tokens = append(tokens, exabgpToken{Text: field, Row: row})
+		}
+		tokens = append(tokens, exabgpToken{Text: EXABGP_END_OF_LINE, Row: row})
+	}
+
+	return tokens
+}
+
+// exabgpStatement returns the attribute tokens of a route statement starting at index,
+// either up to ";" or line end, or within the braces of a route block, and the index
+// following the statement
+func exabgpStatement(tokens []exabgpToken, index int) ([]exabgpToken, int) {
+	attrs := []exabgpToken{}
+	block := index < len(tokens) && tokens[index].Text == "{"
+	if block {
+		index++
+	}
+	for ; index < len(tokens); index++ {
+		switch tokens[index].Text {
+		case ";", EXABGP_END_OF_LINE:
+			if !block {
+				return attrs, index + 1
+			}
+		case "}":
+			if block {
+				return attrs, index + 1
+			}
+			return attrs, index
+		default:
+			attrs = append(attrs, tokens[index])
+		}
+	}
+
+	return attrs, index
+}
+
+// parseExabgpRoute parses the prefix and attributes of a route statement
+func parseExabgpRoute(prefix exabgpToken, attrs []exabgpToken) (*Route, error) {
+	ip, length, err := parsePrefix(prefix.Text)
+	if err != nil {
+		return nil, fmt.Errorf("%v (line %d)", err, prefix.Row+1)
+	}
+	route := &Route{Row: prefix.Row, Network: ip, PrefixLen: length}
+	for i := 0; i < len(attrs); i++ {
+		name, row := attrs[i].Text, attrs[i].Row
+		if exabgpFlags[name] {
+			if name == EXABGP_WITHDRAW {
+				return nil, fmt.Errorf("route %s withdrawn (line %d)", prefix.Text, row+1)
+			}
+			log.Debug().Msgf("attribute %s not supported (line %d)", name, row+1)
+			continue
+		}
+		values := []string{}
+		if i+1 < len(attrs) && attrs[i+1].Text == "[" {
+			// list value, "( )" enclosing AS sets of AS paths
+			for i += 2; i < len(attrs) && attrs[i].Text != "]"; i++ {
+				values = append(values, attrs[i].Text)
+			}
+			if i == len(attrs) {
+				return nil, fmt.Errorf("unterminated list of %s (line %d)", name, row+1)
+			}
+		} else if i+1 < len(attrs) {
+			i++
+			values = append(values, attrs[i].Text)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("no value of %s (line %d)", name, row+1)
+		}
+
+		switch name {
+		case EXABGP_NEXT_HOP:
+			if values[0] != EXABGP_NEXT_HOP_SELF {
+				if net.ParseIP(values[0]) == nil {
+					err = fmt.Errorf("invalid next hop %q", values[0])
+				}
+				route.NextHop = values[0]
+			}
+		case EXABGP_AS_PATH:
+			path := strings.Join(values, " ")
+			path = strings.NewReplacer("( ", "{", " )", "}").Replace(path)
+			route.AsPath, err = parseAsSegments(path, prefix.Row)
+		case EXABGP_ORIGIN:
+			route.Origin, err = parseOriginName(values[0])
+		case EXABGP_MED:
+			route.Metric, err = parseBirdValue(values[0])
+		case EXABGP_LOCAL_PREF:
+			route.LocalPref, err = parseBirdValue(values[0])
+		case EXABGP_COMMUNITY:
+			for _, c := range values {
+				if _, err = communityValue(c); err != nil {
+					break
+				}
+				route.Communities = append(route.Communities, c)
+			}
+		case EXABGP_PATH_INFO:
+			// path id as number or in IPv4 notation
+			if ip := net.ParseIP(values[0]).To4(); ip != nil {
+				route.PathId = uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+			} else if id, perr := strconv.ParseUint(values[0], 10, 32); perr == nil {
+				route.PathId = uint32(id)
+			} else {
+				err = fmt.Errorf("invalid path information %q", values[0])
+			}
+		default:
+			log.Debug().Msgf("attribute %s not supported (line %d)", name, row+1)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v (line %d)", err, row+1)
+		}
+	}
+
+	return route, nil
+}
@@ -0,0 +1,129 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestParseRoutesExabgp(t *testing.T) {
+	filename := "resource/exabgp.conf"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeExabgp)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "exa"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	// route with invalid med skipped
+	names := []string{}
+	for _, route := range *routes {
+		names = append(names, route.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"exa-9", "exa-10", "exa-17", "exa-29"}) {
+		t.Errorf("Unexpected routes %v", names)
+		return
+	}
+
+	first, block, v6, internal := (*routes)[0], (*routes)[1], (*routes)[2], (*routes)[3]
+	expectedPath := []routeimporter.AsPathSegment{
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{65010, 65020}},
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{65030, 65031}},
+	}
+	if first.NextHop != "192.0.2.10" || *first.Metric != 20 || first.Internal ||
+		!reflect.DeepEqual(first.AsPath, expectedPath) ||
+		!reflect.DeepEqual(first.Communities, []string{"65010:1", "no-export"}) {
+		t.Errorf("Unexpected first route %+v", first)
+	}
+	if block.Network.String() != "10.31.0.0" || block.NextHop != "" || *block.LocalPref != 150 ||
+		block.Origin != gosnappi.BgpRouteAdvancedOrigin.EGP || !reflect.DeepEqual(block.Communities, []string{"65010:2"}) {
+		t.Errorf("Unexpected route block %+v", block)
+	}
+	if v6.Network.String() != "2001:db8:300::" || v6.PrefixLen != 48 || v6.NextHop != "2001:db8::10" {
+		t.Errorf("Unexpected IPv6 route %+v", v6)
+	}
+	if !internal.Internal || *internal.LocalPref != 200 || internal.PathId != 7 {
+		t.Errorf("Unexpected internal route %+v", internal)
+	}
+
+	if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "exabgp" {
+		t.Errorf("Expected exabgp format detected, found %q, error: %v", name, err)
+	}
+}
+
+func TestImportRoutesExabgpApi(t *testing.T) {
+	// withdrawn route skipped
+	fb := []byte("announce route 10.60.0.0/24 next-hop 192.0.2.7 as-path [ 65007 ]\n" +
+		"announce route 10.61.0.0/24 next-hop self\n" +
+		"withdraw route 10.62.0.0/24 next-hop 192.0.2.7\n")
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65001)
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeExabgp)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "exa",
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if !reflect.DeepEqual(*names, []string{"exa-1", "exa-2"}) {
+		t.Errorf("Unexpected imported routes %v", *names)
+		return
+	}
+	rrs := peer.V4Routes().Items()
+	if rrs[0].NextHopMode() != gosnappi.BgpV4RouteRangeNextHopMode.LOCAL_IP ||
+		rrs[0].AsPath().Segments().Items()[0].AsNumbers()[0] != 65007 {
+		t.Errorf("Unexpected route range %s", rrs[0].Name())
+	}
+}
+
+func TestParseRoutesExabgpFlags(t *testing.T) {
+	fb := []byte("route 10.0.0.0/24 next-hop 192.0.2.1 atomic-aggregate local-preference 200;\n" +
+		"route 10.0.1.0/24 next-hop 192.0.2.1 withdraw;\n" +
+		"route 10.0.2.0/24 next-hop 192.0.2.1 atomic-aggregate;\n" +
+		"route 10.0.3.0/24 next-hop 1.1.1.1 as-path [\n" +
+		"route 10.0.4.0/24 next-hop 1.1.1.1 as-path [ x\n")
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeExabgp)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "exa"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	// withdrawn route and unterminated lists skipped, flags take no value
+	if len(*routes) != 2 || (*routes)[1].Network.String() != "10.0.2.0" {
+		t.Errorf("Expected routes 10.0.0.0/24 and 10.0.2.0/24, found %d routes", len(*routes))
+		return
+	}
+	first := (*routes)[0]
+	if first.LocalPref == nil || *first.LocalPref != 200 || first.NextHop != "192.0.2.1" {
+		t.Errorf("Unexpected route %+v", first)
+	}
+}
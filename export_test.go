@@ -0,0 +1,93 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestExportRoutesRoundTrip(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "txImp",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	imported, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.RoutesFromConfig(config)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not read routes from config. error: %v", err))
+		return
+	}
+	if len(*routes) != len(*imported) {
+		t.Errorf("Expected %d routes from config, found %d", len(*imported), len(*routes))
+		return
+	}
+
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	buffer, err := es.ExportRoutes(routes)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+	fmt.Printf("Exported routes:\n%s\n", string(*buffer))
+
+	reimported, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "reImp"}, buffer)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse exported routes. error: %v", err))
+		return
+	}
+	if len(*reimported) != len(*imported) {
+		t.Errorf("Expected %d exported routes, found %d", len(*imported), len(*reimported))
+		return
+	}
+	for i, exp := range *imported {
+		got := (*reimported)[i]
+		if !exp.Network.Equal(got.Network) || exp.PrefixLen != got.PrefixLen || exp.NextHop != got.NextHop {
+			t.Errorf("Route %d mismatch. Expected %s/%d via %s, found %s/%d via %s", i,
+				exp.Network, exp.PrefixLen, exp.NextHop, got.Network, got.PrefixLen, got.NextHop)
+		}
+		if *exp.Metric != *got.Metric || *exp.LocalPref != *got.LocalPref {
+			t.Errorf("Route %d mismatch. Expected metric %d locPrf %d, found metric %d locPrf %d", i,
+				*exp.Metric, *exp.LocalPref, *got.Metric, *got.LocalPref)
+		}
+		if exp.Origin != got.Origin || !reflect.DeepEqual(exp.AsPath, got.AsPath) {
+			t.Errorf("Route %d mismatch. Expected path %v %v, found %v %v", i,
+				exp.AsPath, exp.Origin, got.AsPath, got.Origin)
+		}
+	}
+}
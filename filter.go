@@ -0,0 +1,106 @@
+package routeimporter
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// routeFilter removes bogon routes and routes of unselected RPKI states
+type routeFilter struct {
+	ic *ImportConfig
+
+	roas         *roaTable
+	bogons       []*net.IPNet
+	bogonAsns    [][2]uint32
+	defaultRoute bool
+}
+
+// DefaultBogonFilter returns the built-in bogon filter with special purpose, private and
+// documentation prefixes (RFC 6890) and reserved, private and documentation AS numbers
+// (RFC 7607, RFC 6793, RFC 5398, RFC 6996, RFC 7300). Lists can be extended before use.
+func DefaultBogonFilter() BogonFilter {
+	return BogonFilter{
+		Prefixes: []string{
+			"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+			"172.16.0.0/12", "192.0.0.0/24", "192.0.2.0/24", "192.168.0.0/16", "198.18.0.0/15",
+			"198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+			"::/8", "100::/64", "2001:2::/48", "2001:10::/28", "2001:db8::/32", "2002::/16",
+			"3ffe::/16", "3fff::/20", "fc00::/7", "fe80::/10", "fec0::/10", "ff00::/8",
+		},
+		Asns: []string{
+			"0", "23456", "64496-64511", "64512-65534", "65535", "65536-65551", "65552-131071",
+			"4200000000-4294967294", "4294967295",
+		},
+		DefaultRoute: true,
+	}
+}
+
+func newRouteFilter(ic *ImportConfig) (*routeFilter, error) {
+	f := &routeFilter{ic: ic}
+	if len(ic.Roas) > 0 {
+		f.roas = newRoaTable(ic.Roas)
+		f.roas.localAs = rpkiLocalAs(ic)
+	}
+	if ic.Bogons == nil {
+		return f, nil
+	}
+
+	f.defaultRoute = ic.Bogons.DefaultRoute
+	for _, prefix := range ic.Bogons.Prefixes {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(prefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bogon prefix %q", prefix)
+		}
+		f.bogons = append(f.bogons, network)
+	}
+	for _, asns := range ic.Bogons.Asns {
+		bounds := strings.SplitN(asns, "-", 2)
+		first, err := parseAsNumber(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bogon AS number %q", asns)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseAsNumber(strings.TrimSpace(bounds[1])); err != nil || last < first {
+				return nil, fmt.Errorf("invalid bogon AS number range %q", asns)
+			}
+		}
+		f.bogonAsns = append(f.bogonAsns, [2]uint32{first, last})
+	}
+
+	return f, nil
+}
+
+// check validates the route against ROAs and returns reason of removal, or empty
+// string if the route is to be imported
+func (f *routeFilter) check(route *Route) string {
+	if route.Network != nil {
+		if f.defaultRoute && route.PrefixLen == 0 {
+			return "default route"
+		}
+		for _, bogon := range f.bogons {
+			length, _ := bogon.Mask.Size()
+			if route.PrefixLen >= length && bogon.Contains(route.Network) {
+				return fmt.Sprintf("bogon prefix %s", bogon)
+			}
+		}
+	}
+	for _, seg := range route.AsPath {
+		for _, as := range seg.AsNumbers {
+			for _, asns := range f.bogonAsns {
+				if as >= asns[0] && as <= asns[1] {
+					return fmt.Sprintf("bogon AS %d", as)
+				}
+			}
+		}
+	}
+	if f.roas != nil {
+		route.RpkiState = f.roas.validate(route)
+		if !isSelectedRpkiState(f.ic, route.RpkiState) {
+			return fmt.Sprintf("rpki %s", route.RpkiState)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,68 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+)
+
+func TestImportRoutesBogonFilter(t *testing.T) {
+	filename := "resource/cisco_v4_bogons.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	bogons := routeimporter.DefaultBogonFilter()
+	bogons.Prefixes = append(bogons.Prefixes, "9.9.9.0/24")
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "bogon",
+		RRType:     routeimporter.RouteTypeIpv4,
+		Bogons:     &bogons,
+		Report:     &report,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+
+	networks := []string{}
+	for _, route := range *routes {
+		networks = append(networks, fmt.Sprintf("%s/%d", route.Network, route.PrefixLen))
+	}
+	if !reflect.DeepEqual(networks, []string{"8.8.4.0/24", "208.67.222.0/24"}) {
+		t.Errorf("Unexpected imported routes %v", networks)
+	}
+
+	expFiltered := map[string]string{
+		"0.0.0.0/0":     "default route",
+		"8.8.8.0/24":    "bogon AS 64512",
+		"9.9.9.0/24":    "bogon prefix 9.9.9.0/24",
+		"10.1.0.0/16":   "bogon prefix 10.0.0.0/8",
+		"100.64.1.0/24": "bogon prefix 100.64.0.0/10",
+		"192.0.2.0/24":  "bogon prefix 192.0.2.0/24",
+	}
+	filtered := map[string]string{}
+	for _, f := range report.Filtered {
+		filtered[fmt.Sprintf("%s/%d", f.Route.Network, f.Route.PrefixLen)] = f.Reason
+	}
+	if !reflect.DeepEqual(filtered, expFiltered) {
+		t.Errorf("Expected filtered routes %v, found %v", expFiltered, filtered)
+	}
+
+	bogons.Asns = append(bogons.Asns, "invalid")
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for invalid bogon AS number")
+	}
+}
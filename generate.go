@@ -0,0 +1,443 @@
+package routeimporter
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	GENERATE_NEXT_HOP_V4        = "192.0.2.1"
+	GENERATE_NEXT_HOP_V6        = "2001:db8::1"
+	GENERATE_LOCAL_PREF         = 100
+	GENERATE_MAX_ATTEMPTS       = 100
+	GENERATE_4BYTE_AS_PERCENT   = 35
+	GENERATE_TRANSIT_AS_RATIO   = 100
+	GENERATE_MIN_TRANSIT_AS     = 16
+	GENERATE_TRANSIT_AS_SKEW    = 1.0
+	GENERATE_MAX_ORIGIN_AS_SKEW = 3.0
+	GENERATE_MAX_COMMUNITIES    = 9000
+	GENERATE_MAX_ORIGIN_AS      = 250000
+)
+
+// TableProfile specifies the shape of a generated route table, distributions are
+// relative weights, e.g. as reported by Analyze
+type TableProfile struct {
+	Ipv4PrefixLengths map[int]int    `json:"ipv4_prefix_lengths"` // Weight per IPv4 prefix length
+	Ipv6PrefixLengths map[int]int    `json:"ipv6_prefix_lengths"` // Weight per IPv6 prefix length
+	AsPathLengths     map[int]int    `json:"as_path_lengths"`     // Weight per AS path length
+	Origins           map[string]int `json:"origins"`             // Weight per origin code (igp, egp, incomplete)
+	OriginAsCount     int            `json:"origin_as_count"`     // Unique origin ASes
+	OriginAsSkew      float64        `json:"origin_as_skew"`      // Zipf exponent of routes per origin AS, 0 for even spread
+	CommunityCounts   map[int]int    `json:"community_counts"`    // Weight per number of communities of a route
+}
+
+// TableGenerator generates synthetic Internet-like route tables
+type TableGenerator struct {
+	Profile    *TableProfile // Table profile, unset fields use DefaultTableProfile
+	Ipv4Routes int           // IPv4 routes to generate
+	Ipv6Routes int           // IPv6 routes to generate
+	Seed       int64         // Random seed, same seed and profile generate the same table
+}
+
+// weightedChoice picks indexes with probability proportional to their weight
+type weightedChoice struct {
+	cumulative []float64
+}
+
+// asPool draws ASes of a Zipf distribution, ASes are allocated on first draw
+type asPool struct {
+	choice *weightedChoice
+	asns   map[int]uint32
+	used   map[uint32]bool
+	wide   bool
+}
+
+// DefaultTableProfile returns a profile resembling a full Internet table as seen
+// by a transit customer
+func DefaultTableProfile() TableProfile {
+	return TableProfile{
+		Ipv4PrefixLengths: map[int]int{
+			8: 12, 11: 90, 12: 290, 13: 580, 14: 1100, 15: 1900, 16: 13000, 17: 8000, 18: 13500,
+			19: 24000, 20: 42000, 21: 52000, 22: 120000, 23: 110000, 24: 560000,
+		},
+		Ipv6PrefixLengths: map[int]int{
+			19: 2, 20: 60, 24: 200, 28: 2500, 29: 8000, 32: 32000, 33: 2500, 34: 2000, 35: 1000,
+			36: 7000, 40: 10000, 44: 7000, 46: 4000, 47: 2000, 48: 95000,
+		},
+		AsPathLengths:   map[int]int{1: 1, 2: 8, 3: 30, 4: 30, 5: 18, 6: 8, 7: 3, 8: 1, 9: 1},
+		Origins:         map[string]int{"igp": 90, "incomplete": 10},
+		OriginAsCount:   75000,
+		OriginAsSkew:    0.7,
+		CommunityCounts: map[int]int{0: 35, 1: 15, 2: 15, 3: 12, 4: 10, 6: 8, 10: 5},
+	}
+}
+
+// ProfileFromStats learns a table profile from the stats of a real table, distributions
+// missing in the stats use DefaultTableProfile
+func ProfileFromStats(stats *TableStats) (*TableProfile, error) {
+	if stats == nil {
+		return nil, fmt.Errorf("cannot learn profile - no stats")
+	}
+	profile := DefaultTableProfile()
+	if len(stats.Ipv4PrefixLengths) > 0 {
+		profile.Ipv4PrefixLengths = stats.Ipv4PrefixLengths
+	}
+	if len(stats.Ipv6PrefixLengths) > 0 {
+		profile.Ipv6PrefixLengths = stats.Ipv6PrefixLengths
+	}
+	if len(stats.AsPathLengths) > 0 {
+		profile.AsPathLengths = stats.AsPathLengths
+	}
+	if len(stats.Origins) > 0 {
+		profile.Origins = stats.Origins
+	}
+	if len(stats.CommunityCounts) > 0 {
+		profile.CommunityCounts = stats.CommunityCounts
+	}
+	if stats.OriginAsCount > 0 {
+		profile.OriginAsCount = stats.OriginAsCount
+	}
+	if skew, ok := zipfSkew(stats.TopOriginAs); ok {
+		profile.OriginAsSkew = skew
+	}
+
+	return &profile, nil
+}
+
+// zipfSkew fits the Zipf exponent of routes per origin AS rank by least squares on log scale
+func zipfSkew(top []AsCount) (float64, bool) {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i, as := range top {
+		if as.Routes <= 0 {
+			break
+		}
+		x, y := math.Log(float64(i+1)), math.Log(float64(as.Routes))
+		n, sumX, sumY, sumXY, sumXX = n+1, sumX+x, sumY+y, sumXY+x*y, sumXX+x*x
+	}
+	if n < 2 {
+		return 0, false
+	}
+	skew := -(n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+
+	return math.Max(0, math.Min(skew, GENERATE_MAX_ORIGIN_AS_SKEW)), true
+}
+
+// GenerateRoutes returns generated routes named and filtered as per import config,
+// as ParseRoutes of an importer does
+func (g *TableGenerator) GenerateRoutes(ic ImportConfig) (*[]Route, error) {
+	startTask := time.Now()
+	profile, err := g.profile()
+	if err != nil {
+		return nil, err
+	}
+	if g.Ipv4Routes < 0 || g.Ipv6Routes < 0 {
+		return nil, fmt.Errorf("cannot generate - negative route count")
+	}
+	if g.Ipv4Routes == 0 && g.Ipv6Routes == 0 {
+		return nil, fmt.Errorf("cannot generate - no routes requested")
+	}
+
+	rng := rand.New(rand.NewSource(g.Seed))
+	bogons := []*net.IPNet{}
+	for _, prefix := range DefaultBogonFilter().Prefixes {
+		_, ipNet, _ := net.ParseCIDR(prefix)
+		bogons = append(bogons, ipNet)
+	}
+	v4Lengths, v4Choice, err := intWeights(profile.Ipv4PrefixLengths, 32, "IPv4 prefix length")
+	if err != nil {
+		return nil, err
+	}
+	v6Lengths, v6Choice, err := intWeights(profile.Ipv6PrefixLengths, 128, "IPv6 prefix length")
+	if err != nil {
+		return nil, err
+	}
+	pathLengths, pathChoice, err := intWeights(profile.AsPathLengths, -1, "AS path length")
+	if err != nil {
+		return nil, err
+	}
+	communityCounts, communityChoice, err := intWeights(profile.CommunityCounts, -1, "community count")
+	if err != nil {
+		return nil, err
+	}
+	origins, originChoice, err := originWeights(profile.Origins)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[uint32]bool{}
+	transitCount := profile.OriginAsCount / GENERATE_TRANSIT_AS_RATIO
+	if transitCount < GENERATE_MIN_TRANSIT_AS {
+		transitCount = GENERATE_MIN_TRANSIT_AS
+	}
+	transits := newAsPool(transitCount, GENERATE_TRANSIT_AS_SKEW, false, used)
+	originAses := newAsPool(profile.OriginAsCount, profile.OriginAsSkew, true, used)
+
+	routes := make([]Route, 0, g.Ipv4Routes+g.Ipv6Routes)
+	prefixes := map[string]bool{}
+	for i := 0; i < g.Ipv4Routes+g.Ipv6Routes; i++ {
+		locPrf := uint32(GENERATE_LOCAL_PREF)
+		route := Route{Row: i, Best: true, LocalPref: &locPrf, Origin: origins[originChoice.pick(rng)]}
+		if i < g.Ipv4Routes {
+			route.PrefixLen, route.NextHop = v4Lengths[v4Choice.pick(rng)], GENERATE_NEXT_HOP_V4
+		} else {
+			route.PrefixLen, route.NextHop = v6Lengths[v6Choice.pick(rng)], GENERATE_NEXT_HOP_V6
+		}
+		if route.Network, err = uniqueNetwork(rng, route.PrefixLen, i >= g.Ipv4Routes, bogons, prefixes); err != nil {
+			return nil, err
+		}
+
+		if length := pathLengths[pathChoice.pick(rng)]; length > 0 {
+			path := make([]uint32, 0, length)
+			for len(path) < length-1 {
+				as := transits.pick(rng)
+				if len(path) > 0 && path[len(path)-1] == as {
+					continue
+				}
+				path = append(path, as)
+			}
+			path = append(path, originAses.pick(rng))
+			route.AsPath = []AsPathSegment{{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: path}}
+			route.Communities = generateCommunities(rng, path[0], communityCounts[communityChoice.pick(rng)])
+		}
+		routes = append(routes, route)
+	}
+	log.Info().Int64("milisecs", time.Since(startTask).Milliseconds()).Msgf("Generated %d routes", len(routes))
+
+	routes, err = processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// ImportRoutes generates routes and adds them to the target peers of the import config
+func (g *TableGenerator) ImportRoutes(ic ImportConfig) (*[]string, error) {
+	routes, err := g.GenerateRoutes(ic)
+	if err != nil {
+		return nil, err
+	}
+
+	startTask := time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		name, err := merger.add(&(*routes)[i])
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// profile returns the generator profile with unset fields taken from DefaultTableProfile
+func (g *TableGenerator) profile() (*TableProfile, error) {
+	profile := DefaultTableProfile()
+	if g.Profile == nil {
+		return &profile, nil
+	}
+	if g.Profile.Ipv4PrefixLengths != nil {
+		profile.Ipv4PrefixLengths = g.Profile.Ipv4PrefixLengths
+	}
+	if g.Profile.Ipv6PrefixLengths != nil {
+		profile.Ipv6PrefixLengths = g.Profile.Ipv6PrefixLengths
+	}
+	if g.Profile.AsPathLengths != nil {
+		profile.AsPathLengths = g.Profile.AsPathLengths
+	}
+	if g.Profile.Origins != nil {
+		profile.Origins = g.Profile.Origins
+	}
+	if g.Profile.CommunityCounts != nil {
+		profile.CommunityCounts = g.Profile.CommunityCounts
+	}
+	if g.Profile.OriginAsCount < 0 || g.Profile.OriginAsCount > GENERATE_MAX_ORIGIN_AS || g.Profile.OriginAsSkew < 0 {
+		return nil, fmt.Errorf("invalid profile - origin AS count %d or skew %v out of range",
+			g.Profile.OriginAsCount, g.Profile.OriginAsSkew)
+	}
+	if g.Profile.OriginAsCount > 0 {
+		profile.OriginAsCount = g.Profile.OriginAsCount
+		profile.OriginAsSkew = g.Profile.OriginAsSkew
+	}
+
+	return &profile, nil
+}
+
+// intWeights returns the sorted keys of weights and their choice, keys above max are invalid
+func intWeights(weights map[int]int, max int, title string) ([]int, *weightedChoice, error) {
+	keys := []int{}
+	for key := range weights {
+		if key < 0 || (max >= 0 && key > max) {
+			return nil, nil, fmt.Errorf("invalid profile - %s %d", title, key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	values := []float64{}
+	for _, key := range keys {
+		values = append(values, float64(weights[key]))
+	}
+	choice, err := newWeightedChoice(values)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid profile - %s %v", title, err)
+	}
+
+	return keys, choice, nil
+}
+
+// originWeights returns origin codes of weights and their choice
+func originWeights(weights map[string]int) ([]gosnappi.BgpRouteAdvancedOriginEnum, *weightedChoice, error) {
+	codes := map[string]gosnappi.BgpRouteAdvancedOriginEnum{
+		"igp":        gosnappi.BgpRouteAdvancedOrigin.IGP,
+		"egp":        gosnappi.BgpRouteAdvancedOrigin.EGP,
+		"incomplete": gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE,
+	}
+	keys := []string{}
+	for key := range weights {
+		if _, ok := codes[key]; !ok {
+			return nil, nil, fmt.Errorf("invalid profile - origin %q", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	origins := []gosnappi.BgpRouteAdvancedOriginEnum{}
+	values := []float64{}
+	for _, key := range keys {
+		origins = append(origins, codes[key])
+		values = append(values, float64(weights[key]))
+	}
+	choice, err := newWeightedChoice(values)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid profile - origin %v", err)
+	}
+
+	return origins, choice, nil
+}
+
+func newWeightedChoice(weights []float64) (*weightedChoice, error) {
+	choice := &weightedChoice{}
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("weight %v is negative", weight)
+		}
+		total += weight
+		choice.cumulative = append(choice.cumulative, total)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("weights are missing")
+	}
+
+	return choice, nil
+}
+
+func (c *weightedChoice) pick(rng *rand.Rand) int {
+	target := rng.Float64() * c.cumulative[len(c.cumulative)-1]
+	return sort.Search(len(c.cumulative), func(i int) bool { return c.cumulative[i] > target })
+}
+
+// newAsPool creates a pool of count ASes, 4 byte ASes are included if wide
+func newAsPool(count int, skew float64, wide bool, used map[uint32]bool) *asPool {
+	weights := make([]float64, count)
+	for i := range weights {
+		weights[i] = math.Pow(float64(i+1), -skew)
+	}
+	choice, _ := newWeightedChoice(weights)
+
+	return &asPool{choice: choice, asns: map[int]uint32{}, used: used, wide: wide}
+}
+
+func (p *asPool) pick(rng *rand.Rand) uint32 {
+	rank := p.choice.pick(rng)
+	if as, ok := p.asns[rank]; ok {
+		return as
+	}
+	for {
+		// public 2 byte ASes, and 4 byte ASes of the allocated range
+		as := uint32(1 + rng.Intn(64495))
+		if p.wide && rng.Intn(100) < GENERATE_4BYTE_AS_PERCENT {
+			as = uint32(131072 + rng.Intn(268928))
+		}
+		if as != 23456 && !p.used[as] {
+			p.used[as] = true
+			p.asns[rank] = as
+			return as
+		}
+	}
+}
+
+// uniqueNetwork returns a random public network of prefix length, not generated before
+func uniqueNetwork(rng *rand.Rand, prefixLen int, v6 bool, bogons []*net.IPNet, prefixes map[string]bool) (net.IP, error) {
+	for attempt := 0; attempt < GENERATE_MAX_ATTEMPTS; attempt++ {
+		var ipNet net.IPNet
+		if v6 {
+			ip := make(net.IP, net.IPv6len)
+			rng.Read(ip)
+			// global unicast 2000::/3
+			ip[0] = 0x20 | ip[0]&0x1F
+			ipNet.Mask = net.CIDRMask(prefixLen, 128)
+			ipNet.IP = ip.Mask(ipNet.Mask)
+		} else {
+			// unicast 1.0.0.0 - 223.255.255.255
+			ip := make(net.IP, net.IPv4len)
+			rng.Read(ip)
+			ip[0] = byte(1 + rng.Intn(223))
+			ipNet.Mask = net.CIDRMask(prefixLen, 32)
+			ipNet.IP = ip.Mask(ipNet.Mask)
+		}
+		if prefixes[ipNet.String()] {
+			continue
+		}
+		bogon := false
+		for _, b := range bogons {
+			if b.Contains(ipNet.IP) || ipNet.Contains(b.IP) {
+				bogon = true
+				break
+			}
+		}
+		if bogon {
+			continue
+		}
+		prefixes[ipNet.String()] = true
+		return ipNet.IP, nil
+	}
+
+	return nil, fmt.Errorf("cannot generate unique /%d network in %d attempts", prefixLen, GENERATE_MAX_ATTEMPTS)
+}
+
+// generateCommunities returns count communities tagged by the neighbor AS, none for 4 byte ASes
+func generateCommunities(rng *rand.Rand, as uint32, count int) []string {
+	if count == 0 || as > 0xFFFF {
+		return nil
+	}
+	if count > GENERATE_MAX_COMMUNITIES {
+		count = GENERATE_MAX_COMMUNITIES
+	}
+	values := map[int]bool{}
+	communities := []string{}
+	for len(communities) < count {
+		value := 1000 + rng.Intn(GENERATE_MAX_COMMUNITIES)
+		if values[value] {
+			continue
+		}
+		values[value] = true
+		communities = append(communities, fmt.Sprintf("%d:%d", as, value))
+	}
+
+	return communities
+}
@@ -0,0 +1,188 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestGenerateRoutes(t *testing.T) {
+	g := routeimporter.TableGenerator{Ipv4Routes: 2000, Ipv6Routes: 500, Seed: 7}
+	bogons := routeimporter.DefaultBogonFilter()
+	report := routeimporter.ImportReport{}
+	routes, err := g.GenerateRoutes(routeimporter.ImportConfig{NamePrefix: "gen", Bogons: &bogons, Report: &report})
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not generate routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 2500 || len(report.Filtered) != 0 {
+		t.Errorf("Expected 2500 routes and no bogons, found %d routes, %d bogons", len(*routes), len(report.Filtered))
+		return
+	}
+	if (*routes)[0].Name != "gen-1" || (*routes)[2499].Name != "gen-2500" {
+		t.Errorf("Unexpected route names %s, %s", (*routes)[0].Name, (*routes)[2499].Name)
+	}
+
+	again, err := g.GenerateRoutes(routeimporter.ImportConfig{NamePrefix: "gen"})
+	if err != nil || !reflect.DeepEqual(*again, *routes) {
+		t.Errorf("Expected same routes for same seed, error: %v", err)
+	}
+
+	stats, err := routeimporter.Analyze(routes, 5)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not analyze routes. error: %v", err))
+		return
+	}
+	if stats.Prefixes != 2500 || stats.Multipath != 0 {
+		t.Errorf("Expected 2500 unique prefixes, found %d, %d multipath", stats.Prefixes, stats.Multipath)
+	}
+	// default profile has 60% /24 and 45% /48, with AS path length 3 or 4 for 60% of routes
+	if share := stats.Ipv4PrefixLengths[24] * 100 / 2000; share < 50 || share > 70 {
+		t.Errorf("Unexpected /24 share %d%%", share)
+	}
+	if share := stats.Ipv6PrefixLengths[48] * 100 / 500; share < 35 || share > 55 {
+		t.Errorf("Unexpected /48 share %d%%", share)
+	}
+	if share := (stats.AsPathLengths[3] + stats.AsPathLengths[4]) * 100 / 2500; share < 50 || share > 70 {
+		t.Errorf("Unexpected AS path length 3-4 share %d%%", share)
+	}
+	if stats.Attributes["communities"] == 0 || stats.TopOriginAs[0].Routes < 2*stats.TopOriginAs[4].Routes {
+		t.Errorf("Expected communities and skewed origin ASes, found %v, %v", stats.Attributes, stats.TopOriginAs)
+	}
+}
+
+func TestGenerateRoutesLearnedProfile(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "real"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	stats, err := routeimporter.Analyze(routes, 0)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not analyze routes. error: %v", err))
+		return
+	}
+	profile, err := routeimporter.ProfileFromStats(stats)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not learn profile. error: %v", err))
+		return
+	}
+
+	g := routeimporter.TableGenerator{Profile: profile, Ipv4Routes: 100, Seed: 1}
+	generated, err := g.GenerateRoutes(routeimporter.ImportConfig{NamePrefix: "gen"})
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not generate routes. error: %v", err))
+		return
+	}
+	genStats, err := routeimporter.Analyze(generated, 0)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not analyze routes. error: %v", err))
+		return
+	}
+	if !reflect.DeepEqual(genStats.Ipv4PrefixLengths, map[int]int{24: 100}) {
+		t.Errorf("Unexpected prefix lengths %v", genStats.Ipv4PrefixLengths)
+	}
+	if len(genStats.AsPathLengths) != 2 || genStats.AsPathLengths[1]+genStats.AsPathLengths[4] != 100 {
+		t.Errorf("Unexpected AS path lengths %v", genStats.AsPathLengths)
+	}
+	if genStats.OriginAsCount > stats.OriginAsCount {
+		t.Errorf("Expected at most %d origin ASes, found %d", stats.OriginAsCount, genStats.OriginAsCount)
+	}
+}
+
+func TestGenerateRoutesInvalidProfile(t *testing.T) {
+	for _, profile := range []routeimporter.TableProfile{
+		{Ipv4PrefixLengths: map[int]int{33: 1}},
+		{Ipv4PrefixLengths: map[int]int{}},
+		{Origins: map[string]int{"bgp": 1}},
+		{OriginAsCount: -1},
+	} {
+		profile := profile
+		g := routeimporter.TableGenerator{Profile: &profile, Ipv4Routes: 10}
+		if _, err := g.GenerateRoutes(routeimporter.ImportConfig{}); err == nil {
+			t.Errorf("Expected error for profile %+v", profile)
+		}
+	}
+}
+
+func TestGenerateRoutesImport(t *testing.T) {
+	config := gosnappi.NewConfig()
+	bgp := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1")
+	peer := bgp.Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	peerV6 := bgp.Ipv6Interfaces().Add().SetIpv6Name("intA6").Peers().Add().SetName("peerA6")
+	peerV6.SetPeerAddress("2001::1").SetAsType(gosnappi.BgpV6PeerAsType.IBGP).SetAsNumber(65001)
+
+	g := routeimporter.TableGenerator{Ipv4Routes: 50, Ipv6Routes: 20, Seed: 3}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "gen",
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		Targetv6Peers: []gosnappi.BgpV6Peer{peerV6},
+	}
+	generated, err := g.GenerateRoutes(ic)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not generate routes. error: %v", err))
+		return
+	}
+	names, err := g.ImportRoutes(ic)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 70 || len(peer.V4Routes().Items()) != 50 || len(peerV6.V6Routes().Items()) != 20 {
+		t.Errorf("Expected 50 v4 and 20 v6 route ranges, found %d names, %d v4, %d v6",
+			len(*names), len(peer.V4Routes().Items()), len(peerV6.V6Routes().Items()))
+		return
+	}
+
+	routes, err := routeimporter.RoutesFromConfig(config)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not read routes from config. error: %v", err))
+		return
+	}
+	diff, err := routeimporter.DiffTables(generated, routes)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not diff routes. error: %v", err))
+		return
+	}
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Modified) != 0 {
+		t.Errorf("Expected generated routes on peers, found diff %s", diff.String())
+	}
+
+	// cisco text of generated routes for the cisco importer, IPv4 only
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	text, err := es.ExportRoutes(generated)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	parsed, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "gen"}, text)
+	if err != nil || len(*parsed) != 50 {
+		t.Errorf("Expected 50 routes from cisco text, error: %v", err)
+	}
+}
@@ -3,13 +3,13 @@ module github.com/open-traffic-generator/routeimporter
 go 1.19
 
 require (
+	github.com/ghodss/yaml v1.0.0
 	github.com/open-traffic-generator/snappi/gosnappi v0.12.2-0.20230824074723-f77f74aa0ff7
 	github.com/rs/zerolog v1.20.0
 )
 
 require (
 	github.com/Masterminds/semver/v3 v3.2.1 // indirect
-	github.com/ghodss/yaml v1.0.0 // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
 	golang.org/x/net v0.9.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
@@ -0,0 +1,242 @@
+package routeimporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	GOBGP_ATTR_ORIGIN         = 1
+	GOBGP_ATTR_AS_PATH        = 2
+	GOBGP_ATTR_NEXT_HOP       = 3
+	GOBGP_ATTR_MED            = 4
+	GOBGP_ATTR_LOCAL_PREF     = 5
+	GOBGP_ATTR_COMMUNITIES    = 8
+	GOBGP_ATTR_MP_REACH_NLRI  = 14
+	GOBGP_ORIGIN_IGP          = 0
+	GOBGP_ORIGIN_EGP          = 1
+	GOBGP_ORIGIN_INCOMPLETE   = 2
+	GOBGP_SEGMENT_AS_SET      = 1
+	GOBGP_SEGMENT_AS_SEQ      = 2
+	GOBGP_SEGMENT_CONFED_SEQ  = 3
+	GOBGP_SEGMENT_CONFED_SET  = 4
+	GOBGP_DETECT_NLRI_KEY     = `"nlri"`
+	GOBGP_DETECT_ATTRS_KEY    = `"attrs"`
+	GOBGP_DETECT_LENGTH_BYTES = 4096
+)
+
+// gobgpSegmentTypes maps GoBGP AS path segment types
+var gobgpSegmentTypes = map[int]gosnappi.BgpAsPathSegmentTypeEnum{
+	GOBGP_SEGMENT_AS_SET:     gosnappi.BgpAsPathSegmentType.AS_SET,
+	GOBGP_SEGMENT_AS_SEQ:     gosnappi.BgpAsPathSegmentType.AS_SEQ,
+	GOBGP_SEGMENT_CONFED_SEQ: gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ,
+	GOBGP_SEGMENT_CONFED_SET: gosnappi.BgpAsPathSegmentType.AS_CONFED_SET,
+}
+
+// gobgpOrigins maps GoBGP origin values
+var gobgpOrigins = map[int]gosnappi.BgpRouteAdvancedOriginEnum{
+	GOBGP_ORIGIN_IGP:        gosnappi.BgpRouteAdvancedOrigin.IGP,
+	GOBGP_ORIGIN_EGP:        gosnappi.BgpRouteAdvancedOrigin.EGP,
+	GOBGP_ORIGIN_INCOMPLETE: gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE,
+}
+
+// GobgpImporter imports routes from gobgp global rib -j / gobgp neighbor <ip> adj-in -j output
+type GobgpImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+}
+
+// gobgpPath holds a path of GoBGP JSON output
+type gobgpPath struct {
+	Nlri struct {
+		Prefix string `json:"prefix"`
+	} `json:"nlri"`
+	Best  bool        `json:"best"`
+	Id    uint32      `json:"id"`
+	Attrs []gobgpAttr `json:"attrs"`
+}
+
+// gobgpAttr holds a path attribute of GoBGP JSON output, fields as per attribute type
+type gobgpAttr struct {
+	Type    int             `json:"type"`
+	Value   json.RawMessage `json:"value"`
+	AsPaths []struct {
+		SegmentType int      `json:"segment_type"`
+		Asns        []uint32 `json:"asns"`
+	} `json:"as_paths"`
+	Nexthop     string   `json:"nexthop"`
+	Metric      *uint32  `json:"metric"`
+	Communities []uint32 `json:"communities"`
+}
+
+// String returns the id of the client.
+func (imp *GobgpImporter) String() string {
+	return fmt.Sprintf("GoBGP Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for a JSON document with NLRI and path attributes of GoBGP paths
+func (imp *GobgpImporter) Detect(buffer *[]byte) bool {
+	head := bytes.TrimSpace(*buffer)
+	if len(head) == 0 || (head[0] != '{' && head[0] != '[') {
+		return false
+	}
+	if len(head) > GOBGP_DETECT_LENGTH_BYTES {
+		head = head[:GOBGP_DETECT_LENGTH_BYTES]
+	}
+
+	return bytes.Contains(head, []byte(GOBGP_DETECT_NLRI_KEY)) && bytes.Contains(head, []byte(GOBGP_DETECT_ATTRS_KEY))
+}
+
+// ImportRoutes adds one route range per path, IPv4 routes to the target v4 peer and
+// IPv6 routes to the target v6 peer.
+func (imp *GobgpImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses paths of GoBGP JSON output, either destinations keyed by prefix with
+// their paths or a list of paths, in the order found. Routes are named by sequence.
+func (imp *GobgpImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	paths, err := decodeGobgpPaths(*buffer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot import - %v", err)
+	}
+	routes := []Route{}
+	for index, path := range paths {
+		route, err := path.route(index)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		if ic.BestRoutes && !route.Best {
+			continue
+		}
+		if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+			(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+			continue
+		}
+		routes = append(routes, *route)
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+
+	routes, err = processRoutes(&ic, routes, SEQ_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// decodeGobgpPaths decodes the paths of destinations keyed by prefix, keeping their order,
+// or of a list of paths
+func decodeGobgpPaths(buffer []byte) ([]gobgpPath, error) {
+	paths := []gobgpPath{}
+	if trimmed := bytes.TrimSpace(buffer); len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &paths)
+		return paths, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(buffer))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("no GoBGP destinations found")
+	}
+	for decoder.More() {
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		destination := []gobgpPath{}
+		if err := decoder.Decode(&destination); err != nil {
+			return nil, err
+		}
+		paths = append(paths, destination...)
+	}
+
+	return paths, nil
+}
+
+// route returns the route of a path, index numbering the path in the output
+func (path *gobgpPath) route(index int) (*Route, error) {
+	ip, length, err := parsePrefix(path.Nlri.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%v (path %d)", err, index+1)
+	}
+	route := &Route{Row: index, Network: ip, PrefixLen: length, Best: path.Best, PathId: path.Id}
+	for _, attr := range path.Attrs {
+		switch attr.Type {
+		case GOBGP_ATTR_ORIGIN:
+			value := 0
+			if err := json.Unmarshal(attr.Value, &value); err != nil {
+				return nil, fmt.Errorf("invalid origin (path %d)", index+1)
+			}
+			origin, ok := gobgpOrigins[value]
+			if !ok {
+				return nil, fmt.Errorf("unknown origin %d (path %d)", value, index+1)
+			}
+			route.Origin = origin
+		case GOBGP_ATTR_AS_PATH:
+			for _, seg := range attr.AsPaths {
+				segType, ok := gobgpSegmentTypes[seg.SegmentType]
+				if !ok {
+					return nil, fmt.Errorf("unknown AS path segment type %d (path %d)", seg.SegmentType, index+1)
+				}
+				route.AsPath = append(route.AsPath, AsPathSegment{Type: segType, AsNumbers: seg.Asns})
+			}
+		case GOBGP_ATTR_NEXT_HOP, GOBGP_ATTR_MP_REACH_NLRI:
+			route.NextHop = attr.Nexthop
+		case GOBGP_ATTR_MED:
+			route.Metric = attr.Metric
+		case GOBGP_ATTR_LOCAL_PREF:
+			value := uint32(0)
+			if err := json.Unmarshal(attr.Value, &value); err != nil {
+				return nil, fmt.Errorf("invalid local pref (path %d)", index+1)
+			}
+			route.LocalPref = &value
+		case GOBGP_ATTR_COMMUNITIES:
+			for _, c := range attr.Communities {
+				route.Communities = append(route.Communities, communityString(c))
+			}
+		}
+	}
+
+	return route, nil
+}
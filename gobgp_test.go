@@ -0,0 +1,106 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestParseRoutesGobgp(t *testing.T) {
+	filename := "resource/gobgp_rib.json"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeGobgp)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "gobgp"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 4 {
+		t.Errorf("Expected 4 routes, found %d", len(*routes))
+		return
+	}
+
+	first, second, v6 := (*routes)[0], (*routes)[1], (*routes)[3]
+	if !first.Best || first.Network.String() != "10.10.0.0" || first.PrefixLen != 24 || first.NextHop != "192.0.2.1" ||
+		*first.Metric != 10 || *first.LocalPref != 200 || first.Origin != gosnappi.BgpRouteAdvancedOrigin.IGP ||
+		!reflect.DeepEqual(first.AsPath[0].AsNumbers, []uint32{65001, 65002}) ||
+		!reflect.DeepEqual(first.Communities, []string{"65000:100", "no-export"}) {
+		t.Errorf("Unexpected first route %+v", first)
+	}
+	expectedPath := []routeimporter.AsPathSegment{
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ, AsNumbers: []uint32{65003}},
+		{Type: gosnappi.BgpAsPathSegmentType.AS_SET, AsNumbers: []uint32{65010, 65011}},
+	}
+	if second.Best || second.NextHop != "192.0.2.2" || second.Metric != nil || second.LocalPref != nil ||
+		second.Origin != gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE || !reflect.DeepEqual(second.AsPath, expectedPath) {
+		t.Errorf("Unexpected second route %+v", second)
+	}
+	if v6.Network.String() != "2001:db8:100::" || v6.PrefixLen != 48 || v6.NextHop != "2001:db8::1" {
+		t.Errorf("Unexpected IPv6 route %+v", v6)
+	}
+
+	ic := routeimporter.ImportConfig{NamePrefix: "gobgp", BestRoutes: true, RRType: routeimporter.RouteTypeIpv4}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 2 || (*routes)[1].Network.String() != "10.20.0.0" {
+		t.Errorf("Expected 2 best IPv4 routes, found %d", len(*routes))
+	}
+
+	if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "gobgp" {
+		t.Errorf("Expected gobgp format detected, found %q, error: %v", name, err)
+	}
+}
+
+func TestImportRoutesGobgpPathList(t *testing.T) {
+	fb := []byte(`[
+  {"nlri": {"prefix": "10.50.0.0/24"}, "best": true, "attrs": [{"type": 3, "nexthop": "192.0.2.5"}]},
+  {"nlri": {"prefix": "10.51.0.0/24"}, "best": true, "attrs": [{"type": 2, "as_paths": [{"segment_type": 9, "asns": [1]}]}]},
+  {"nlri": {"prefix": "10.52.0.0/24"}, "best": true, "id": 3, "attrs": [{"type": 3, "nexthop": "192.0.2.6"}]}
+]`)
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65001)
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeGobgp)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "gobgp",
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	// path with unknown segment type skipped
+	if len(*names) != 2 {
+		t.Errorf("Expected 2 imported routes, found %v", *names)
+		return
+	}
+	rrs := peer.V4Routes().Items()
+	if rrs[0].NextHopIpv4Address() != "192.0.2.5" || rrs[1].NextHopIpv4Address() != "192.0.2.6" {
+		t.Errorf("Unexpected route ranges %s, %s", rrs[0].NextHopIpv4Address(), rrs[1].NextHopIpv4Address())
+	}
+}
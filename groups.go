@@ -0,0 +1,194 @@
+package routeimporter
+
+import (
+	"fmt"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// routeGroup holds route names of a route group in import order
+type routeGroup struct {
+	Name   string
+	Routes []string
+}
+
+// validateRouteGroups checks route group parameters of the import config
+func validateRouteGroups(ic *ImportConfig) error {
+	if ic.RouteGroups == RouteGroupChunk && ic.RouteGroupSize <= 0 {
+		return fmt.Errorf("invalid route group size %d, must be positive for chunk route groups", ic.RouteGroupSize)
+	}
+
+	return nil
+}
+
+// routeGroups groups the imported routes as per route group mode of the import config,
+// names holds route range name of each route
+func routeGroups(ic *ImportConfig, routes []*Route, names []string) []routeGroup {
+	groups := []routeGroup{}
+	index := map[string]int{}
+	for i, route := range routes {
+		var name string
+		switch ic.RouteGroups {
+		case RouteGroupAll:
+			name = fmt.Sprintf("%s-all", ic.NamePrefix)
+		case RouteGroupOriginAs:
+			if as, _, ok := originAs(route); ok {
+				name = fmt.Sprintf("%s-as%d", ic.NamePrefix, as)
+			} else {
+				name = fmt.Sprintf("%s-as-local", ic.NamePrefix)
+			}
+		case RouteGroupPrefixLen:
+			name = fmt.Sprintf("%s-len%d", ic.NamePrefix, route.PrefixLen)
+		case RouteGroupNextHop:
+			if route.NextHop != "" {
+				name = fmt.Sprintf("%s-nh-%s", ic.NamePrefix, route.NextHop)
+			} else {
+				name = fmt.Sprintf("%s-nh-local", ic.NamePrefix)
+			}
+		case RouteGroupChunk:
+			name = fmt.Sprintf("%s-chunk%d", ic.NamePrefix, i/ic.RouteGroupSize+1)
+		case RouteGroupRpki:
+			name = fmt.Sprintf("%s-rpki-%s", ic.NamePrefix, route.RpkiState)
+		case RouteGroupWeight:
+			weight := uint32(0)
+			if route.Weight != nil {
+				weight = *route.Weight
+			}
+			name = fmt.Sprintf("%s-weight%d", ic.NamePrefix, weight)
+		default:
+			return groups
+		}
+		if _, ok := index[name]; !ok {
+			index[name] = len(groups)
+			groups = append(groups, routeGroup{Name: name})
+		}
+		groups[index[name]].Routes = append(groups[index[name]].Routes, names[i])
+	}
+
+	return groups
+}
+
+// originAs returns the last AS of the AS path, confederation segments excluded, ok is false
+// for locally originated routes. Paths ending with an AS_SET return the last AS of the set with
+// asSet true, grouping and naming use it as origin while RPKI validation treats it as none.
+func originAs(route *Route) (as uint32, asSet bool, ok bool) {
+	for i := len(route.AsPath) - 1; i >= 0; i-- {
+		seg := route.AsPath[i]
+		if seg.Type == gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ ||
+			seg.Type == gosnappi.BgpAsPathSegmentType.AS_CONFED_SET || len(seg.AsNumbers) == 0 {
+			continue
+		}
+		return seg.AsNumbers[len(seg.AsNumbers)-1], seg.Type == gosnappi.BgpAsPathSegmentType.AS_SET, true
+	}
+
+	return 0, false, false
+}
+
+// pruneV4RouteGroups drops route names of the route groups of the peer not naming a route
+// range of the peer, and route groups left without route names
+func pruneV4RouteGroups(peer gosnappi.BgpV4Peer) {
+	found := map[string]bool{}
+	for _, rr := range peer.V4Routes().Items() {
+		found[rr.Name()] = true
+	}
+	for _, rr := range peer.V6Routes().Items() {
+		found[rr.Name()] = true
+	}
+	kept := []gosnappi.DeviceBgpV4RouteGroup{}
+	for _, rg := range peer.V4RouteGroups().Items() {
+		if names := existingRouteNames(rg.RouteNames(), found); len(names) > 0 {
+			kept = append(kept, rg.SetRouteNames(names))
+		}
+	}
+	peer.V4RouteGroups().Clear().Append(kept...)
+}
+
+// pruneV6RouteGroups drops route names of the route groups of the peer not naming a route
+// range of the peer, and route groups left without route names
+func pruneV6RouteGroups(peer gosnappi.BgpV6Peer) {
+	found := map[string]bool{}
+	for _, rr := range peer.V4Routes().Items() {
+		found[rr.Name()] = true
+	}
+	for _, rr := range peer.V6Routes().Items() {
+		found[rr.Name()] = true
+	}
+	kept := []gosnappi.DeviceBgpV6RouteGroup{}
+	for _, rg := range peer.V6RouteGroups().Items() {
+		if names := existingRouteNames(rg.RouteNames(), found); len(names) > 0 {
+			kept = append(kept, rg.SetRouteNames(names))
+		}
+	}
+	peer.V6RouteGroups().Clear().Append(kept...)
+}
+
+// existingRouteNames returns the route names found
+func existingRouteNames(names []string, found map[string]bool) []string {
+	existing := []string{}
+	for _, name := range names {
+		if found[name] {
+			existing = append(existing, name)
+		}
+	}
+
+	return existing
+}
+
+// mergeRouteNames returns existing route names followed by new route names not already present
+func mergeRouteNames(existing []string, names []string) []string {
+	merged := append([]string{}, existing...)
+	found := map[string]bool{}
+	for _, name := range existing {
+		found[name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			found[name] = true
+			merged = append(merged, name)
+		}
+	}
+
+	return merged
+}
+
+// addV4RouteGroups adds route groups to the peer, route names are merged into
+// existing route groups of same name unless replace is set
+func addV4RouteGroups(peer gosnappi.BgpV4Peer, groups []routeGroup, replace bool) {
+	for _, group := range groups {
+		var rg gosnappi.DeviceBgpV4RouteGroup
+		for _, existing := range peer.V4RouteGroups().Items() {
+			if existing.Name() == group.Name {
+				rg = existing
+				break
+			}
+		}
+		if rg == nil {
+			peer.V4RouteGroups().Add().SetName(group.Name).SetRouteNames(group.Routes)
+		} else if replace {
+			rg.SetRouteNames(group.Routes)
+		} else {
+			rg.SetRouteNames(mergeRouteNames(rg.RouteNames(), group.Routes))
+		}
+	}
+}
+
+// addV6RouteGroups adds route groups to the peer, route names are merged into
+// existing route groups of same name unless replace is set
+func addV6RouteGroups(peer gosnappi.BgpV6Peer, groups []routeGroup, replace bool) {
+	for _, group := range groups {
+		var rg gosnappi.DeviceBgpV6RouteGroup
+		for _, existing := range peer.V6RouteGroups().Items() {
+			if existing.Name() == group.Name {
+				rg = existing
+				break
+			}
+		}
+		if rg == nil {
+			peer.V6RouteGroups().Add().SetName(group.Name).SetRouteNames(group.Routes)
+		} else if replace {
+			rg.SetRouteNames(group.Routes)
+		} else {
+			rg.SetRouteNames(mergeRouteNames(rg.RouteNames(), group.Routes))
+		}
+	}
+}
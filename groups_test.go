@@ -0,0 +1,127 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesRouteGroups(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	tests := []struct {
+		Mode   routeimporter.RouteGroupMode
+		Groups map[string]int
+	}{
+		{routeimporter.RouteGroupAll, map[string]int{"grp-all": 6}},
+		{routeimporter.RouteGroupOriginAs, map[string]int{"grp-as15169": 3, "grp-as56203": 3}},
+		{routeimporter.RouteGroupPrefixLen, map[string]int{"grp-len24": 6}},
+		{routeimporter.RouteGroupNextHop, map[string]int{
+			"grp-nh-67.16.148.37": 4, "grp-nh-67.16.148.38": 1, "grp-nh-67.16.148.40": 1}},
+		{routeimporter.RouteGroupChunk, map[string]int{"grp-chunk1": 4, "grp-chunk2": 2}},
+	}
+	for _, test := range tests {
+		config := gosnappi.NewConfig()
+		peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+			Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+		peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+		ic := routeimporter.ImportConfig{
+			NamePrefix:     "grp",
+			RRType:         routeimporter.RouteTypeIpv4,
+			RetainNexthop:  true,
+			Targetv4Peers:  []gosnappi.BgpV4Peer{peer},
+			RouteGroups:    test.Mode,
+			RouteGroupSize: 4,
+		}
+		is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+			return
+		}
+		if _, err := is.ImportRoutes(ic, &fb); err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+
+		groups := peer.V4RouteGroups().Items()
+		if len(groups) != len(test.Groups) {
+			t.Errorf("Route group mode %d: expected %d route groups, found %d", test.Mode, len(test.Groups), len(groups))
+			continue
+		}
+		ranges := map[string]bool{}
+		for _, rr := range peer.V4Routes().Items() {
+			ranges[rr.Name()] = true
+		}
+		for _, group := range groups {
+			if count, ok := test.Groups[group.Name()]; !ok || count != len(group.RouteNames()) {
+				t.Errorf("Route group mode %d: unexpected route group %s with %d routes", test.Mode,
+					group.Name(), len(group.RouteNames()))
+			}
+			for _, name := range group.RouteNames() {
+				if !ranges[name] {
+					t.Errorf("Route group %s: route range %s not found", group.Name(), name)
+				}
+			}
+		}
+		if _, err := config.ToJson(); err != nil {
+			t.Errorf("failed to convert config in Json format. Error: %v", err.Error())
+		}
+	}
+}
+
+func TestImportRoutesRouteGroupsReplace(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	peer.V4Routes().Add().SetName("old-1").Addresses().Add().SetAddress("10.0.0.0").SetPrefix(24)
+	peer.V6Routes().Add().SetName("old-v6").Addresses().Add().SetAddress("2001:db8::").SetPrefix(32)
+	peer.V4RouteGroups().Add().SetName("old-group").SetRouteNames([]string{"old-1"})
+	peer.V4RouteGroups().Add().SetName("mixed").SetRouteNames([]string{"old-1", "old-v6"})
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "grp",
+		RetainNexthop: true,
+		MergeMode:     routeimporter.MergeModeReplace,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		RouteGroups:   routeimporter.RouteGroupAll,
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+
+	// stale route group dropped, route names of replaced route ranges removed
+	groups := map[string][]string{}
+	for _, group := range peer.V4RouteGroups().Items() {
+		groups[group.Name()] = group.RouteNames()
+	}
+	if _, ok := groups["old-group"]; ok || len(groups) != 2 || len(groups["grp-all"]) != 6 ||
+		len(groups["mixed"]) != 1 || groups["mixed"][0] != "old-v6" {
+		t.Errorf("Unexpected route groups %v", groups)
+	}
+	if _, err := config.ToJson(); err != nil {
+		t.Errorf("failed to convert config in Json format. Error: %v", err.Error())
+	}
+}
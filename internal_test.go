@@ -0,0 +1,115 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesSplitInternal(t *testing.T) {
+	filename := "resource/cisco_v4_internal.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	bgp := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1")
+	intf := bgp.Ipv4Interfaces().Add().SetIpv4Name("intA")
+	ibgp := intf.Peers().Add().SetName("peerI")
+	ibgp.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	ebgp := intf.Peers().Add().SetName("peerE")
+	ebgp.SetPeerAddress("1.1.1.2").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65002)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "split",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{ibgp, ebgp},
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err == nil {
+		t.Errorf("Expected error for multiple target peers without SplitInternal")
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	internal := []bool{}
+	for _, route := range *routes {
+		internal = append(internal, route.Internal)
+	}
+	if !reflect.DeepEqual(internal, []bool{true, true, false, true, true, false}) {
+		t.Errorf("Unexpected internal flags %v", internal)
+	}
+
+	ic.SplitInternal = true
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 6 {
+		t.Errorf("Expected 6 imported routes, found %d", len(*names))
+	}
+	ibgpNames, ebgpNames := []string{}, []string{}
+	for _, rr := range ibgp.V4Routes().Items() {
+		ibgpNames = append(ibgpNames, rr.Name())
+		if rr.NextHopMode() != gosnappi.BgpV4RouteRangeNextHopMode.MANUAL ||
+			rr.AsPath().AsSetMode() == gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ {
+			t.Errorf("Expected retained next hop and AS path for iBGP route %s", rr.Name())
+		}
+	}
+	for _, rr := range ebgp.V4Routes().Items() {
+		ebgpNames = append(ebgpNames, rr.Name())
+		if rr.NextHopMode() != gosnappi.BgpV4RouteRangeNextHopMode.LOCAL_IP ||
+			rr.AsPath().AsSetMode() != gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ {
+			t.Errorf("Expected local next hop and own AS prepended for eBGP route %s", rr.Name())
+		}
+	}
+	if !reflect.DeepEqual(ibgpNames, []string{"split-8", "split-9", "split-11", "split-12"}) ||
+		!reflect.DeepEqual(ebgpNames, []string{"split-10", "split-13"}) {
+		t.Errorf("Unexpected iBGP routes %v, eBGP routes %v", ibgpNames, ebgpNames)
+	}
+
+	fromConfig, err := routeimporter.RoutesFromConfig(config)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not read routes from config. error: %v", err))
+		return
+	}
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	exported, err := es.ExportRoutes(fromConfig)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+	reparsed, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "split"}, exported)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse exported routes. error: %v", err))
+		return
+	}
+	internalCount := 0
+	for _, route := range *reparsed {
+		if route.Internal {
+			internalCount++
+		}
+	}
+	if len(*reparsed) != 6 || internalCount != 4 {
+		t.Errorf("Expected 4 of 6 exported routes internal, found %d of %d", internalCount, len(*reparsed))
+	}
+}
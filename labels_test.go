@@ -0,0 +1,115 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type LabelEntry struct {
+	Network  string
+	NextHop  string
+	InLabel  int64
+	OutLabel int64
+}
+
+func TestImportRoutesVpnV4Labels(t *testing.T) {
+	filename := "resource/cisco_vpnv4_labels.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	peer := gosnappi.NewBgpV4Peer()
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "txImp",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	validateLabels(t, routes, []LabelEntry{
+		{Network: "10.1.1.0", NextHop: "192.168.1.2", InLabel: 16, OutLabel: -1},
+		{Network: "10.1.2.0", NextHop: "10.0.0.2", InLabel: -1, OutLabel: 17},
+		{Network: "10.1.2.0", NextHop: "10.0.0.3", InLabel: -1, OutLabel: 18},
+		{Network: "10.2.2.0", NextHop: "10.0.0.2", InLabel: -1, OutLabel: 3},
+	})
+
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(peer.V4Routes().Items()) != 4 || !peer.Capability().Ipv4MplsVpn() {
+		t.Errorf("Expected 4 routes with MPLS VPN capability, found %d routes", len(peer.V4Routes().Items()))
+	}
+}
+
+func TestImportRoutesLabelTable(t *testing.T) {
+	filename := "resource/xr_labeled_unicast_labels.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "luImp",
+		RRType:     routeimporter.RouteTypeIpv4,
+		BestRoutes: true,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	validateLabels(t, routes, []LabelEntry{
+		{Network: "10.0.0.2", NextHop: "10.0.0.2", InLabel: 24001, OutLabel: 3},
+		{Network: "10.0.0.3", NextHop: "10.0.0.3", InLabel: 24002, OutLabel: 16003},
+		{Network: "10.0.0.1", NextHop: "0.0.0.0", InLabel: 3, OutLabel: -1},
+	})
+}
+
+func validateLabels(t *testing.T, routes *[]routeimporter.Route, expRoutes []LabelEntry) {
+	if len(*routes) != len(expRoutes) {
+		t.Errorf("Expected Route Count: %d, Parsed Routes Count: %d", len(expRoutes), len(*routes))
+		return
+	}
+	label := func(l *uint32) int64 {
+		if l == nil {
+			return -1
+		}
+		return int64(*l)
+	}
+	for i, exp := range expRoutes {
+		route := (*routes)[i]
+		found := LabelEntry{
+			Network:  route.Network.String(),
+			NextHop:  route.NextHop,
+			InLabel:  label(route.InLabel),
+			OutLabel: label(route.OutLabel),
+		}
+		if found != exp {
+			t.Errorf("Route mismatch at %d. Expected: %v, Parsed: %v", i, exp, found)
+		}
+	}
+}
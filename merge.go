@@ -0,0 +1,570 @@
+package routeimporter
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// routeMerger adds route ranges of imported routes to the target peers, or the target
+// IS-IS router, as per merge mode
+type routeMerger struct {
+	ic     *ImportConfig
+	peerV4 gosnappi.BgpV4Peer
+	peerV6 gosnappi.BgpV6Peer
+	isis   gosnappi.DeviceIsisRouter
+
+	ranges *rangeMatcher
+	report *ImportReport
+
+	// merged routes and their route range names, for route groups
+	routesV4 []*Route
+	namesV4  []string
+	routesV6 []*Route
+	namesV6  []string
+}
+
+// targetMerger merges routes into the target peers of the import config, internal and
+// external routes into the iBGP and eBGP target peers if SplitInternal is set, and routes
+// with target peer name into the named target peer
+type targetMerger struct {
+	internal *routeMerger
+	external *routeMerger
+	peers    map[string]*routeMerger
+
+	mergers []*routeMerger
+}
+
+func newTargetMerger(ic *ImportConfig) (*targetMerger, error) {
+	if !ic.SplitInternal {
+		peerV4, peerV6, err := targetPeers(ic)
+		if err != nil {
+			return nil, err
+		}
+		m, err := newRouteMerger(ic, peerV4, peerV6)
+		if err != nil {
+			return nil, err
+		}
+		return &targetMerger{internal: m, external: m, mergers: []*routeMerger{m}}, nil
+	}
+
+	ibgpV4, ibgpV6, ebgpV4, ebgpV6, err := splitTargetPeers(ic)
+	if err != nil {
+		return nil, err
+	}
+	internal, err := newRouteMerger(ic, ibgpV4, ibgpV6)
+	if err != nil {
+		return nil, err
+	}
+	external, err := newRouteMerger(ic, ebgpV4, ebgpV6)
+	if err != nil {
+		return nil, err
+	}
+	shareMergers(internal, external)
+
+	return &targetMerger{internal: internal, external: external, mergers: []*routeMerger{internal, external}}, nil
+}
+
+// newIsisTargetMerger merges routes into the v4 / v6 route ranges of the target IS-IS router
+func newIsisTargetMerger(ic *ImportConfig) (*targetMerger, error) {
+	if len(ic.TargetIsisRouters) > 1 {
+		// To be handled in future
+		return nil, fmt.Errorf("multiple target IS-IS routers currently not supported")
+	}
+	if len(ic.TargetIsisRouters) == 0 {
+		return nil, fmt.Errorf("cannot import, no target IS-IS routers found")
+	}
+	if ic.RouteGroups != RouteGroupNone {
+		return nil, fmt.Errorf("cannot import - route groups not supported for IS-IS routers")
+	}
+	router := ic.TargetIsisRouters[0]
+	m := &routeMerger{
+		ic:     ic,
+		isis:   router,
+		ranges: newIsisRangeMatcher(ic, router),
+		report: &ImportReport{},
+	}
+	if ic.MergeMode == MergeModeReplace {
+		m.report.Removed += len(router.V4Routes().Items()) + len(router.V6Routes().Items())
+		router.V4Routes().Clear()
+		router.V6Routes().Clear()
+	}
+
+	return &targetMerger{internal: m, external: m, mergers: []*routeMerger{m}}, nil
+}
+
+// newPeerTargetMerger merges routes with target peer name into the v4 / v6 target peers of
+// that name. Other routes are merged as by newTargetMerger into the target peers that
+// no route names.
+// Routes without target peer name are not imported if all target peers are named.
+func newPeerTargetMerger(ic *ImportConfig, routes []Route) (*targetMerger, error) {
+	peerNames := []string{}
+	found := map[string]bool{}
+	unnamed := false
+	for i := range routes {
+		if routes[i].Peer == "" {
+			unnamed = true
+		} else if !found[routes[i].Peer] {
+			found[routes[i].Peer] = true
+			peerNames = append(peerNames, routes[i].Peer)
+		}
+	}
+	if len(peerNames) == 0 {
+		return newTargetMerger(ic)
+	}
+
+	t := &targetMerger{peers: map[string]*routeMerger{}}
+	if unnamed {
+		other := *ic
+		other.Targetv4Peers, other.Targetv6Peers = nil, nil
+		for _, peer := range ic.Targetv4Peers {
+			if !found[peer.Name()] {
+				other.Targetv4Peers = append(other.Targetv4Peers, peer)
+			}
+		}
+		for _, peer := range ic.Targetv6Peers {
+			if !found[peer.Name()] {
+				other.Targetv6Peers = append(other.Targetv6Peers, peer)
+			}
+		}
+		if len(other.Targetv4Peers) > 0 || len(other.Targetv6Peers) > 0 {
+			merger, err := newTargetMerger(&other)
+			if err != nil {
+				return nil, err
+			}
+			t.internal, t.external, t.mergers = merger.internal, merger.external, merger.mergers
+		}
+	}
+	for _, name := range peerNames {
+		var peerV4 gosnappi.BgpV4Peer
+		var peerV6 gosnappi.BgpV6Peer
+		for _, peer := range ic.Targetv4Peers {
+			if peer.Name() == name {
+				peerV4 = peer
+			}
+		}
+		for _, peer := range ic.Targetv6Peers {
+			if peer.Name() == name {
+				peerV6 = peer
+			}
+		}
+		if peerV4 == nil && peerV6 == nil {
+			return nil, fmt.Errorf("cannot import, target peer %q not found", name)
+		}
+		m, err := newRouteMerger(ic, peerV4, peerV6)
+		if err != nil {
+			return nil, err
+		}
+		if len(t.mergers) > 0 {
+			shareMergers(t.mergers[0], m)
+		}
+		t.peers[name] = m
+		t.mergers = append(t.mergers, m)
+	}
+
+	return t, nil
+}
+
+// shareMergers makes route names unique and merge counts shared across the mergers
+func shareMergers(first *routeMerger, others ...*routeMerger) {
+	for _, m := range others {
+		first.ranges.shareNames(m.ranges)
+		first.report.Removed += m.report.Removed
+		m.report = first.report
+	}
+}
+
+// merger returns the merger of the target peers of the route
+func (t *targetMerger) merger(route *Route) *routeMerger {
+	if route.Peer != "" && t.peers != nil {
+		return t.peers[route.Peer]
+	}
+	if route.Internal {
+		return t.internal
+	}
+	return t.external
+}
+
+// add merges the route into route ranges of its target peer
+func (t *targetMerger) add(route *Route) (string, error) {
+	m := t.merger(route)
+	if m == nil {
+		return "", fmt.Errorf("route %s not imported, no target peer", route.Name)
+	}
+	return m.add(route)
+}
+
+// done creates route groups on all target peers and fills the report of the import config
+func (t *targetMerger) done() {
+	for _, m := range t.mergers {
+		m.done()
+	}
+}
+
+// newRouteMerger indexes existing route ranges of the target peers, or removes them
+// for MergeModeReplace
+func newRouteMerger(ic *ImportConfig, peerV4 gosnappi.BgpV4Peer, peerV6 gosnappi.BgpV6Peer) (*routeMerger, error) {
+	if err := validateRouteGroups(ic); err != nil {
+		return nil, err
+	}
+	m := &routeMerger{
+		ic:     ic,
+		peerV4: peerV4,
+		peerV6: peerV6,
+		ranges: newBgpRangeMatcher(ic, peerV4, peerV6),
+		report: &ImportReport{},
+	}
+	if ic.MergeMode == MergeModeReplace {
+		if peerV4 != nil {
+			m.report.Removed += len(peerV4.V4Routes().Items())
+			peerV4.V4Routes().Clear()
+			pruneV4RouteGroups(peerV4)
+		}
+		if peerV6 != nil {
+			m.report.Removed += len(peerV6.V6Routes().Items())
+			peerV6.V6Routes().Clear()
+			pruneV6RouteGroups(peerV6)
+		}
+	}
+	setAsNumberWidth(ic, peerV4, peerV6)
+
+	return m, nil
+}
+
+// setAsNumberWidth sets the AS number width of the import config on the target peers
+func setAsNumberWidth(ic *ImportConfig, peerV4 gosnappi.BgpV4Peer, peerV6 gosnappi.BgpV6Peer) {
+	switch ic.AsNumberWidth {
+	case AsNumberWidthTwo:
+		if peerV4 != nil {
+			peerV4.SetAsNumberWidth(gosnappi.BgpV4PeerAsNumberWidth.TWO)
+		}
+		if peerV6 != nil {
+			peerV6.SetAsNumberWidth(gosnappi.BgpV6PeerAsNumberWidth.TWO)
+		}
+	case AsNumberWidthFour:
+		if peerV4 != nil {
+			peerV4.SetAsNumberWidth(gosnappi.BgpV4PeerAsNumberWidth.FOUR)
+		}
+		if peerV6 != nil {
+			peerV6.SetAsNumberWidth(gosnappi.BgpV6PeerAsNumberWidth.FOUR)
+		}
+	}
+}
+
+// add merges the route into route ranges of the target peer, returns name of the
+// added or matching existing route range. Each existing route range is matched by
+// one route at most, further paths of the prefix are appended.
+func (m *routeMerger) add(route *Route) (string, error) {
+	name, err := m.merge(route)
+	if err != nil {
+		return "", err
+	}
+	if route.Network.To4() != nil {
+		m.routesV4, m.namesV4 = append(m.routesV4, route), append(m.namesV4, name)
+	} else {
+		m.routesV6, m.namesV6 = append(m.routesV6, route), append(m.namesV6, name)
+	}
+
+	return name, nil
+}
+
+// merge adds or updates the route range of the route as per merge mode
+func (m *routeMerger) merge(route *Route) (string, error) {
+	if m.isis != nil {
+		return m.mergeIsis(route)
+	}
+	if route.Network.To4() != nil {
+		if m.peerV4 == nil {
+			return "", fmt.Errorf("route %s not imported, no target v4 peer", route.Name)
+		}
+		rrV4, err := newV4RouteRange(route, m.ic, m.peerV4)
+		if err != nil {
+			return "", err
+		}
+		index, name := m.ranges.match(route, route.Name)
+		rrV4.SetName(name)
+		if index < 0 {
+			m.peerV4.V4Routes().Append(rrV4)
+			m.added(route.Name, name)
+			return name, nil
+		}
+		if m.ic.MergeMode == MergeModeUpsert && changed(m.peerV4.V4Routes().Items()[index], rrV4) {
+			m.peerV4.V4Routes().Set(index, rrV4)
+			m.report.Updated++
+			return name, nil
+		}
+		m.report.Unchanged++
+		return name, nil
+	}
+
+	if m.peerV6 == nil {
+		return "", fmt.Errorf("route %s not imported, no target v6 peer", route.Name)
+	}
+	rrV6, err := newV6RouteRange(route, m.ic, m.peerV6)
+	if err != nil {
+		return "", err
+	}
+	index, name := m.ranges.match(route, route.Name)
+	rrV6.SetName(name)
+	if index < 0 {
+		m.peerV6.V6Routes().Append(rrV6)
+		m.added(route.Name, name)
+		return name, nil
+	}
+	if m.ic.MergeMode == MergeModeUpsert && changed(m.peerV6.V6Routes().Items()[index], rrV6) {
+		m.peerV6.V6Routes().Set(index, rrV6)
+		m.report.Updated++
+		return name, nil
+	}
+	m.report.Unchanged++
+
+	return name, nil
+}
+
+// mergeIsis adds or updates the IS-IS route range of the route as per merge mode
+func (m *routeMerger) mergeIsis(route *Route) (string, error) {
+	if route.Network.To4() != nil {
+		rrV4 := newIsisV4RouteRange(route)
+		index, name := m.ranges.match(route, route.Name)
+		rrV4.SetName(name)
+		if index < 0 {
+			m.isis.V4Routes().Append(rrV4)
+			m.added(route.Name, name)
+			return name, nil
+		}
+		if m.ic.MergeMode == MergeModeUpsert && changed(m.isis.V4Routes().Items()[index], rrV4) {
+			m.isis.V4Routes().Set(index, rrV4)
+			m.report.Updated++
+			return name, nil
+		}
+		m.report.Unchanged++
+		return name, nil
+	}
+
+	rrV6 := newIsisV6RouteRange(route)
+	index, name := m.ranges.match(route, route.Name)
+	rrV6.SetName(name)
+	if index < 0 {
+		m.isis.V6Routes().Append(rrV6)
+		m.added(route.Name, name)
+		return name, nil
+	}
+	if m.ic.MergeMode == MergeModeUpsert && changed(m.isis.V6Routes().Items()[index], rrV6) {
+		m.isis.V6Routes().Set(index, rrV6)
+		m.report.Updated++
+		return name, nil
+	}
+	m.report.Unchanged++
+
+	return name, nil
+}
+
+// added counts a route range added, renamed if its name was already used
+func (m *routeMerger) added(name string, unique string) {
+	m.report.Added++
+	if unique != name {
+		m.report.Renamed++
+	}
+}
+
+// done creates route groups of merged routes and copies merge counts to the report
+// of the import config
+func (m *routeMerger) done() {
+	replace := m.ic.MergeMode == MergeModeReplace
+	if m.peerV4 != nil && len(m.routesV4) > 0 {
+		addV4RouteGroups(m.peerV4, routeGroups(m.ic, m.routesV4, m.namesV4), replace)
+	}
+	if m.peerV6 != nil && len(m.routesV6) > 0 {
+		addV6RouteGroups(m.peerV6, routeGroups(m.ic, m.routesV6, m.namesV6), replace)
+	}
+	if m.ic.Report != nil {
+		// routes filtered while parsing are kept
+		m.report.Filtered, m.report.AsTrans = m.ic.Report.Filtered, m.ic.Report.AsTrans
+		m.report.ScaleSkipped = m.ic.Report.ScaleSkipped
+		*m.ic.Report = *m.report
+	}
+}
+
+// existingRange holds the name of an existing route range and, for single prefix
+// route ranges, its prefix key
+type existingRange struct {
+	name   string
+	prefix string
+}
+
+// rangeMatcher matches routes to existing route ranges of target peers as per merge mode,
+// and names the route ranges added. It is shared by imports and the route names predicted
+// for update streams, so that both name routes alike.
+type rangeMatcher struct {
+	names *nameSet
+
+	// existing v4 / v6 route ranges, and indexes of those matched by prefix, per prefix
+	v4, v6                 []existingRange
+	existingV4, existingV6 map[string][]int
+}
+
+// newRangeMatcher indexes existing v4 / v6 route ranges, removed on import by
+// MergeModeReplace, other names are kept in use
+func newRangeMatcher(ic *ImportConfig, v4 []existingRange, v6 []existingRange, other []string) *rangeMatcher {
+	r := &rangeMatcher{existingV4: map[string][]int{}, existingV6: map[string][]int{}}
+	kept := append([]string{}, other...)
+	if ic.MergeMode != MergeModeReplace {
+		r.v4, r.v6 = v4, v6
+		match := ic.MergeMode == MergeModeUpsert || ic.MergeMode == MergeModeSkip
+		for i, rr := range v4 {
+			kept = append(kept, rr.name)
+			if match && rr.prefix != "" {
+				r.existingV4[rr.prefix] = append(r.existingV4[rr.prefix], i)
+			}
+		}
+		for i, rr := range v6 {
+			kept = append(kept, rr.name)
+			if match && rr.prefix != "" {
+				r.existingV6[rr.prefix] = append(r.existingV6[rr.prefix], i)
+			}
+		}
+	}
+	r.names = newNameSet(kept)
+
+	return r
+}
+
+// newBgpRangeMatcher indexes route ranges of the v4 / v6 target peers
+func newBgpRangeMatcher(ic *ImportConfig, peerV4 gosnappi.BgpV4Peer, peerV6 gosnappi.BgpV6Peer) *rangeMatcher {
+	v4, v6, other := []existingRange{}, []existingRange{}, []string{}
+	if peerV4 != nil {
+		for _, rr := range peerV4.V4Routes().Items() {
+			existing := existingRange{name: rr.Name()}
+			if addrs := rr.Addresses().Items(); len(addrs) == 1 && addrs[0].Count() == 1 {
+				existing.prefix = prefixKey(addrs[0].Address(), addrs[0].Prefix())
+			}
+			v4 = append(v4, existing)
+		}
+		for _, rr := range peerV4.V6Routes().Items() {
+			other = append(other, rr.Name())
+		}
+	}
+	if peerV6 != nil {
+		for _, rr := range peerV6.V4Routes().Items() {
+			other = append(other, rr.Name())
+		}
+		for _, rr := range peerV6.V6Routes().Items() {
+			existing := existingRange{name: rr.Name()}
+			if addrs := rr.Addresses().Items(); len(addrs) == 1 && addrs[0].Count() == 1 {
+				existing.prefix = prefixKey(addrs[0].Address(), addrs[0].Prefix())
+			}
+			v6 = append(v6, existing)
+		}
+	}
+
+	return newRangeMatcher(ic, v4, v6, other)
+}
+
+// newIsisRangeMatcher indexes v4 / v6 route ranges of the target IS-IS router
+func newIsisRangeMatcher(ic *ImportConfig, router gosnappi.DeviceIsisRouter) *rangeMatcher {
+	v4, v6 := []existingRange{}, []existingRange{}
+	for _, rr := range router.V4Routes().Items() {
+		existing := existingRange{name: rr.Name()}
+		if addrs := rr.Addresses().Items(); len(addrs) == 1 && addrs[0].Count() == 1 {
+			existing.prefix = prefixKey(addrs[0].Address(), addrs[0].Prefix())
+		}
+		v4 = append(v4, existing)
+	}
+	for _, rr := range router.V6Routes().Items() {
+		existing := existingRange{name: rr.Name()}
+		if addrs := rr.Addresses().Items(); len(addrs) == 1 && addrs[0].Count() == 1 {
+			existing.prefix = prefixKey(addrs[0].Address(), addrs[0].Prefix())
+		}
+		v6 = append(v6, existing)
+	}
+
+	return newRangeMatcher(ic, v4, v6, nil)
+}
+
+// match returns the index and name of the existing route range matched by the route, or
+// -1 and the name, made unique, of the route range added. Each existing route range is
+// matched by one route at most, further paths of the prefix are added.
+func (r *rangeMatcher) match(route *Route, name string) (int, string) {
+	existing, ranges := r.existingV6, r.v6
+	if route.Network.To4() != nil {
+		existing, ranges = r.existingV4, r.v4
+	}
+	key := prefixKey(route.Network.String(), uint32(route.PrefixLen))
+	if indexes := existing[key]; len(indexes) > 0 && route.Count <= 1 {
+		existing[key] = indexes[1:]
+		return indexes[0], ranges[indexes[0]].name
+	}
+
+	return -1, r.names.unique(name)
+}
+
+// shareNames makes route names unique across the matchers
+func (r *rangeMatcher) shareNames(others ...*rangeMatcher) {
+	for _, other := range others {
+		for name := range other.names.used {
+			r.names.used[name] = true
+		}
+		other.names = r.names
+	}
+}
+
+// mergeNames predicts the route range names routeMerger gives routes on import, without
+// updating the target peers
+type mergeNames struct {
+	internal *rangeMatcher
+	external *rangeMatcher
+}
+
+func newMergeNames(ic *ImportConfig) *mergeNames {
+	if ic.SplitInternal {
+		if ibgpV4, ibgpV6, ebgpV4, ebgpV6, err := splitTargetPeers(ic); err == nil {
+			m := &mergeNames{
+				internal: newBgpRangeMatcher(ic, ibgpV4, ibgpV6),
+				external: newBgpRangeMatcher(ic, ebgpV4, ebgpV6),
+			}
+			m.internal.shareNames(m.external)
+			return m
+		}
+	} else if peerV4, peerV6, err := targetPeers(ic); err == nil {
+		ranges := newBgpRangeMatcher(ic, peerV4, peerV6)
+		return &mergeNames{internal: ranges, external: ranges}
+	}
+	ranges := newRangeMatcher(ic, nil, nil, nil)
+
+	return &mergeNames{internal: ranges, external: ranges}
+}
+
+// name returns the name routeMerger gives the route range of the route
+func (m *mergeNames) name(route *Route, name string) string {
+	ranges := m.external
+	if route.Internal {
+		ranges = m.internal
+	}
+	_, name = ranges.match(route, name)
+
+	return name
+}
+
+// prefixKey returns normalized prefix of a route range address
+func prefixKey(address string, prefix uint32) string {
+	if ip := net.ParseIP(address); ip != nil {
+		address = ip.String()
+	}
+
+	return fmt.Sprintf("%s/%d", address, prefix)
+}
+
+// changed checks if two route ranges differ in any attribute
+func changed(existing interface{ ToJson() (string, error) }, rr interface{ ToJson() (string, error) }) bool {
+	existingJson, err := existing.ToJson()
+	if err != nil {
+		return true
+	}
+	rrJson, err := rr.ToJson()
+	if err != nil {
+		return true
+	}
+
+	return existingJson != rrJson
+}
@@ -0,0 +1,87 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesMergeModes(t *testing.T) {
+	buffers := [][]byte{}
+	for _, filename := range []string{"resource/cisco_v4_basic.txt", "resource/cisco_v4_basic_changed.txt"} {
+		fb, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+			return
+		}
+		buffers = append(buffers, fb)
+	}
+
+	tests := []struct {
+		Mode      routeimporter.MergeMode
+		Report    routeimporter.ImportReport
+		Routes    int
+		LocalPref uint32
+	}{
+		{routeimporter.MergeModeAppend, routeimporter.ImportReport{Added: 6, Renamed: 6}, 12, 300},
+		{routeimporter.MergeModeReplace, routeimporter.ImportReport{Added: 6, Removed: 6}, 6, 350},
+		{routeimporter.MergeModeUpsert, routeimporter.ImportReport{Added: 1, Updated: 1, Unchanged: 4, Renamed: 1}, 7, 350},
+		{routeimporter.MergeModeSkip, routeimporter.ImportReport{Added: 1, Unchanged: 5, Renamed: 1}, 7, 300},
+	}
+	for _, test := range tests {
+		config := gosnappi.NewConfig()
+		peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+			Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+		peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+		report := routeimporter.ImportReport{}
+		ic := routeimporter.ImportConfig{
+			NamePrefix:    "merge",
+			RRType:        routeimporter.RouteTypeIpv4,
+			RetainNexthop: true,
+			Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+			Report:        &report,
+		}
+		is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+			return
+		}
+		if _, err := is.ImportRoutes(ic, &buffers[0]); err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+
+		ic.MergeMode = test.Mode
+		if test.Mode == routeimporter.MergeModeAppend {
+			// same table imported twice
+			_, err = is.ImportRoutes(ic, &buffers[0])
+		} else {
+			_, err = is.ImportRoutes(ic, &buffers[1])
+		}
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+		report.Filtered = nil
+		if !reflect.DeepEqual(report, test.Report) {
+			t.Errorf("Merge mode %d: expected report %+v, found %+v", test.Mode, test.Report, report)
+		}
+		routes := peer.V4Routes().Items()
+		if len(routes) != test.Routes {
+			t.Errorf("Merge mode %d: expected %d route ranges, found %d", test.Mode, test.Routes, len(routes))
+			continue
+		}
+		// fourth path of 1.0.0.0/24 has changed local preference
+		if locPrf := routes[3].Advanced().LocalPreference(); locPrf != test.LocalPref {
+			t.Errorf("Merge mode %d: expected local preference %d, found %d", test.Mode, test.LocalPref, locPrf)
+		}
+		if _, err := config.ToJson(); err != nil {
+			t.Errorf("failed to convert config in Json format. Error: %v", err.Error())
+		}
+	}
+}
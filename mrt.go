@@ -0,0 +1,334 @@
+package routeimporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	MRT_TYPE_TABLE_DUMP_V2 = 13
+
+	MRT_SUBTYPE_PEER_INDEX_TABLE = 1
+	MRT_SUBTYPE_RIB_IPV4_UNICAST = 2
+	MRT_SUBTYPE_RIB_IPV6_UNICAST = 4
+
+	MRT_PEER_TYPE_IPV6 = 0x01
+	MRT_PEER_TYPE_AS4  = 0x02
+
+	BGP_ATTR_FLAG_OPTIONAL   = 0x80
+	BGP_ATTR_FLAG_TRANSITIVE = 0x40
+	BGP_ATTR_FLAG_EXT_LENGTH = 0x10
+
+	BGP_ATTR_ORIGIN        = 1
+	BGP_ATTR_AS_PATH       = 2
+	BGP_ATTR_NEXT_HOP      = 3
+	BGP_ATTR_MED           = 4
+	BGP_ATTR_LOCAL_PREF    = 5
+	BGP_ATTR_COMMUNITIES   = 8
+	BGP_ATTR_MP_REACH_NLRI = 14
+	BGP_ATTR_EXT_COMMUNITY = 16
+
+	BGP_EXT_COMMUNITY_SUBTYPE_ROUTE_TARGET = 0x02
+)
+
+// MrtExporter writes routes as MRT TABLE_DUMP_V2 file, with one peer entry
+// per next hop of the routes
+type MrtExporter struct {
+	id uint64
+
+	// CollectorId is written as collector BGP ID of the peer index table
+	CollectorId string
+	// ViewName is written as view name of the peer index table
+	ViewName string
+
+	exportedRoutes int
+}
+
+type mrtPeer struct {
+	Address net.IP
+	As      uint32
+}
+
+// String returns the id of the client.
+func (exp *MrtExporter) String() string {
+	return fmt.Sprintf("MRT Route Exporter, session id: %8d, exportedRoutes:%d",
+		exp.id, exp.exportedRoutes)
+}
+
+func (exp *MrtExporter) ExportRoutes(routes *[]Route) (*[]byte, error) {
+	if routes == nil {
+		return nil, fmt.Errorf("cannot export - no routes")
+	}
+	timestamp := uint32(time.Now().Unix())
+
+	sorted := []*Route{}
+	expanded := expandRoutes(*routes)
+	for i := range expanded {
+		route := &expanded[i]
+		if route.Evpn != nil || route.Network == nil {
+			log.Info().Msgf("route %s not exported, EVPN routes not supported", route.Name)
+			continue
+		}
+		if route.Rd != "" {
+			log.Info().Msgf("route %s not exported, VPN routes not supported", route.Name)
+			continue
+		}
+		sorted = append(sorted, route)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iv4, jv4 := sorted[i].Network.To4() != nil, sorted[j].Network.To4() != nil
+		if iv4 != jv4 {
+			return iv4
+		}
+		if c := bytes.Compare(normalizeIP(sorted[i].Network), normalizeIP(sorted[j].Network)); c != 0 {
+			return c < 0
+		}
+		return sorted[i].PrefixLen < sorted[j].PrefixLen
+	})
+
+	peers := []mrtPeer{}
+	peerIndex := map[string]uint16{}
+	for _, route := range sorted {
+		nextHop := mrtNextHop(route)
+		if _, ok := peerIndex[nextHop.String()]; ok {
+			continue
+		}
+		if len(peers) == 0xFFFF {
+			return nil, fmt.Errorf("cannot export - more than %d peers", 0xFFFF)
+		}
+		peer := mrtPeer{Address: nextHop}
+		if len(route.AsPath) > 0 && len(route.AsPath[0].AsNumbers) > 0 {
+			peer.As = route.AsPath[0].AsNumbers[0]
+		}
+		peerIndex[nextHop.String()] = uint16(len(peers))
+		peers = append(peers, peer)
+	}
+
+	var b bytes.Buffer
+	table, err := exp.peerIndexTable(peers)
+	if err != nil {
+		return nil, err
+	}
+	writeMrtRecord(&b, timestamp, MRT_SUBTYPE_PEER_INDEX_TABLE, table)
+
+	seq := uint32(0)
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end].Network.Equal(sorted[start].Network) &&
+			sorted[end].PrefixLen == sorted[start].PrefixLen {
+			end++
+		}
+		if end-start > 0xFFFF {
+			return nil, fmt.Errorf("cannot export - more than %d paths for %s/%d",
+				0xFFFF, sorted[start].Network, sorted[start].PrefixLen)
+		}
+
+		var rib bytes.Buffer
+		binary.Write(&rib, binary.BigEndian, seq)
+		subtype := uint16(MRT_SUBTYPE_RIB_IPV4_UNICAST)
+		network := sorted[start].Network.To4()
+		if network == nil {
+			subtype = MRT_SUBTYPE_RIB_IPV6_UNICAST
+			network = sorted[start].Network.To16()
+		}
+		if sorted[start].PrefixLen < 0 || sorted[start].PrefixLen > len(network)*8 {
+			return nil, fmt.Errorf("cannot export - invalid prefix %s/%d of %s",
+				sorted[start].Network, sorted[start].PrefixLen, sorted[start].Name)
+		}
+		rib.WriteByte(byte(sorted[start].PrefixLen))
+		rib.Write(network[:(sorted[start].PrefixLen+7)/8])
+		binary.Write(&rib, binary.BigEndian, uint16(end-start))
+		for _, route := range sorted[start:end] {
+			attrs, err := mrtPathAttributes(route)
+			if err != nil {
+				return nil, err
+			}
+			if len(attrs) > 0xFFFF {
+				return nil, fmt.Errorf("cannot export - path attributes of %s exceed %d bytes", route.Name, 0xFFFF)
+			}
+			binary.Write(&rib, binary.BigEndian, peerIndex[mrtNextHop(route).String()])
+			binary.Write(&rib, binary.BigEndian, timestamp)
+			binary.Write(&rib, binary.BigEndian, uint16(len(attrs)))
+			rib.Write(attrs)
+			exp.exportedRoutes++
+		}
+		writeMrtRecord(&b, timestamp, subtype, rib.Bytes())
+		seq++
+		start = end
+	}
+	buffer := b.Bytes()
+
+	return &buffer, nil
+}
+
+// peerIndexTable encodes PEER_INDEX_TABLE message, all peers with 4 byte AS
+func (exp *MrtExporter) peerIndexTable(peers []mrtPeer) ([]byte, error) {
+	var b bytes.Buffer
+	collectorId := net.IPv4zero.To4()
+	if exp.CollectorId != "" {
+		if collectorId = net.ParseIP(exp.CollectorId).To4(); collectorId == nil {
+			return nil, fmt.Errorf("invalid collector id: %q", exp.CollectorId)
+		}
+	}
+	b.Write(collectorId)
+	binary.Write(&b, binary.BigEndian, uint16(len(exp.ViewName)))
+	b.WriteString(exp.ViewName)
+	binary.Write(&b, binary.BigEndian, uint16(len(peers)))
+	for _, peer := range peers {
+		if v4 := peer.Address.To4(); v4 != nil {
+			b.WriteByte(MRT_PEER_TYPE_AS4)
+			b.Write(v4)
+			b.Write(v4)
+		} else {
+			b.WriteByte(MRT_PEER_TYPE_AS4 | MRT_PEER_TYPE_IPV6)
+			b.Write(net.IPv4zero.To4())
+			b.Write(peer.Address.To16())
+		}
+		binary.Write(&b, binary.BigEndian, peer.As)
+	}
+
+	return b.Bytes(), nil
+}
+
+// mrtNextHop returns the next hop of route, unspecified address if not known
+func mrtNextHop(route *Route) net.IP {
+	if ip := net.ParseIP(route.NextHop); ip != nil {
+		return ip
+	}
+	if route.Network.To4() != nil {
+		return net.IPv4zero
+	}
+
+	return net.IPv6unspecified
+}
+
+// mrtPathAttributes encodes path attributes of route, with 4 byte AS numbers as
+// required in TABLE_DUMP_V2 RIB entries
+func mrtPathAttributes(route *Route) ([]byte, error) {
+	var b bytes.Buffer
+	origin := byte(2)
+	switch route.Origin {
+	case gosnappi.BgpRouteAdvancedOrigin.IGP:
+		origin = 0
+	case gosnappi.BgpRouteAdvancedOrigin.EGP:
+		origin = 1
+	}
+	writeBgpAttribute(&b, BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_ORIGIN, []byte{origin})
+
+	var path bytes.Buffer
+	for _, seg := range route.AsPath {
+		for start := 0; start < len(seg.AsNumbers); start += 255 {
+			end := start + 255
+			if end > len(seg.AsNumbers) {
+				end = len(seg.AsNumbers)
+			}
+			path.WriteByte(asPathSegmentCode(seg.Type))
+			path.WriteByte(byte(end - start))
+			for _, num := range seg.AsNumbers[start:end] {
+				binary.Write(&path, binary.BigEndian, num)
+			}
+		}
+	}
+	writeBgpAttribute(&b, BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_AS_PATH, path.Bytes())
+
+	nextHop := mrtNextHop(route)
+	if route.Network.To4() != nil && nextHop.To4() != nil {
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_NEXT_HOP, nextHop.To4())
+	} else {
+		// RIB entries carry only next hop length and next hop of MP_REACH_NLRI
+		value := nextHop.To16()
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_OPTIONAL, BGP_ATTR_MP_REACH_NLRI,
+			append([]byte{byte(len(value))}, value...))
+	}
+	if route.Metric != nil {
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, *route.Metric)
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_OPTIONAL, BGP_ATTR_MED, value)
+	}
+	if route.LocalPref != nil {
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, *route.LocalPref)
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_LOCAL_PREF, value)
+	}
+	if len(route.Communities) > 0 {
+		value := make([]byte, 0, 4*len(route.Communities))
+		for _, c := range route.Communities {
+			community, err := communityValue(c)
+			if err != nil {
+				return nil, fmt.Errorf("%v (line %d)", err, route.Row+1)
+			}
+			value = binary.BigEndian.AppendUint32(value, community)
+		}
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_OPTIONAL|BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_COMMUNITIES, value)
+	}
+	if len(route.RouteTargets) > 0 {
+		var communities bytes.Buffer
+		for _, rt := range route.RouteTargets {
+			rtType, rtValue, err := routeTargetValue(rt)
+			if err != nil {
+				return nil, fmt.Errorf("%v (line %d)", err, route.Row+1)
+			}
+			value, _ := hex.DecodeString(rtValue)
+			communities.WriteByte(extCommunityTypeCode(rtType))
+			communities.WriteByte(BGP_EXT_COMMUNITY_SUBTYPE_ROUTE_TARGET)
+			communities.Write(value)
+		}
+		writeBgpAttribute(&b, BGP_ATTR_FLAG_OPTIONAL|BGP_ATTR_FLAG_TRANSITIVE, BGP_ATTR_EXT_COMMUNITY,
+			communities.Bytes())
+	}
+
+	return b.Bytes(), nil
+}
+
+func writeMrtRecord(b *bytes.Buffer, timestamp uint32, subtype uint16, message []byte) {
+	binary.Write(b, binary.BigEndian, timestamp)
+	binary.Write(b, binary.BigEndian, uint16(MRT_TYPE_TABLE_DUMP_V2))
+	binary.Write(b, binary.BigEndian, subtype)
+	binary.Write(b, binary.BigEndian, uint32(len(message)))
+	b.Write(message)
+}
+
+func writeBgpAttribute(b *bytes.Buffer, flags byte, attrType byte, value []byte) {
+	if len(value) > 0xFF {
+		b.WriteByte(flags | BGP_ATTR_FLAG_EXT_LENGTH)
+		b.WriteByte(attrType)
+		binary.Write(b, binary.BigEndian, uint16(len(value)))
+	} else {
+		b.WriteByte(flags)
+		b.WriteByte(attrType)
+		b.WriteByte(byte(len(value)))
+	}
+	b.Write(value)
+}
+
+func asPathSegmentCode(segType gosnappi.BgpAsPathSegmentTypeEnum) byte {
+	switch segType {
+	case gosnappi.BgpAsPathSegmentType.AS_SET:
+		return 1
+	case gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ:
+		return 3
+	case gosnappi.BgpAsPathSegmentType.AS_CONFED_SET:
+		return 4
+	default:
+		return 2
+	}
+}
+
+func extCommunityTypeCode(rtType gosnappi.BgpExtCommunityTypeEnum) byte {
+	switch rtType {
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS:
+		return 0x01
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET:
+		return 0x02
+	default:
+		return 0x00
+	}
+}
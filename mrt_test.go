@@ -0,0 +1,136 @@
+package routeimporter_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+)
+
+type MrtRecord struct {
+	Type    uint16
+	Subtype uint16
+	Message []byte
+}
+
+func TestExportRoutesMrt(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "mrt", RetainNexthop: true}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeMrt)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	buffer, err := es.ExportRoutes(routes)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+
+	records := []MrtRecord{}
+	for data := *buffer; len(data) > 0; {
+		if len(data) < 12 {
+			t.Errorf("Truncated MRT header, %d bytes left", len(data))
+			return
+		}
+		length := binary.BigEndian.Uint32(data[8:12])
+		if uint32(len(data)-12) < length {
+			t.Errorf("Truncated MRT record, expected %d bytes, found %d", length, len(data)-12)
+			return
+		}
+		records = append(records, MrtRecord{
+			Type:    binary.BigEndian.Uint16(data[4:6]),
+			Subtype: binary.BigEndian.Uint16(data[6:8]),
+			Message: data[12 : 12+length],
+		})
+		data = data[12+length:]
+	}
+
+	// peer index table and one RIB entry per prefix
+	if len(records) != 3 {
+		t.Errorf("Expected 3 MRT records, found %d", len(records))
+		return
+	}
+	if records[0].Type != 13 || records[0].Subtype != 1 {
+		t.Errorf("Expected PEER_INDEX_TABLE, found type %d subtype %d", records[0].Type, records[0].Subtype)
+	}
+	// collector id, empty view name, peer count
+	if peers := binary.BigEndian.Uint16(records[0].Message[6:8]); peers != 3 {
+		t.Errorf("Expected 3 peers, found %d", peers)
+	}
+
+	expEntries := []struct {
+		Prefix []byte
+		Count  uint16
+	}{
+		{[]byte{24, 1, 0, 0}, 4},
+		{[]byte{24, 1, 0, 5}, 2},
+	}
+	for i, exp := range expEntries {
+		rib := records[i+1]
+		if rib.Type != 13 || rib.Subtype != 2 {
+			t.Errorf("Expected RIB_IPV4_UNICAST, found type %d subtype %d", rib.Type, rib.Subtype)
+			continue
+		}
+		if seq := binary.BigEndian.Uint32(rib.Message[0:4]); seq != uint32(i) {
+			t.Errorf("Expected sequence %d, found %d", i, seq)
+		}
+		if string(rib.Message[4:8]) != string(exp.Prefix) {
+			t.Errorf("Expected prefix %v, found %v", exp.Prefix, rib.Message[4:8])
+		}
+		if count := binary.BigEndian.Uint16(rib.Message[8:10]); count != exp.Count {
+			t.Errorf("Expected %d entries, found %d", exp.Count, count)
+		}
+	}
+
+	// first entry: peer 0, ORIGIN IGP, AS_PATH 15169, NEXT_HOP, MED 50, LOCAL_PREF 200
+	entry := records[1].Message[10:]
+	attrLen := binary.BigEndian.Uint16(entry[6:8])
+	expAttrs := []byte{
+		0x40, 1, 1, 0,
+		0x40, 2, 6, 2, 1, 0, 0, 0x3b, 0x41,
+		0x40, 3, 4, 67, 16, 148, 37,
+		0x80, 4, 4, 0, 0, 0, 50,
+		0x40, 5, 4, 0, 0, 0, 200,
+	}
+	if string(entry[8:8+attrLen]) != string(expAttrs) {
+		t.Errorf("Expected path attributes %v, found %v", expAttrs, entry[8:8+attrLen])
+	}
+}
+
+func TestExportRoutesMrtInvalidPrefix(t *testing.T) {
+	if _, _, err := routeimporter.ParseNetworkAddress("10.0.0.0/40"); err == nil {
+		t.Errorf("Expected error for prefix length 40 of IPv4 network")
+	}
+	if _, _, err := routeimporter.ParseNetworkAddress("2001:db8::/129"); err == nil {
+		t.Errorf("Expected error for prefix length 129 of IPv6 network")
+	}
+
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeMrt)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	routes := []routeimporter.Route{{Name: "r1", Network: net.ParseIP("10.0.0.0"), PrefixLen: 40}}
+	if _, err := es.ExportRoutes(&routes); err == nil {
+		t.Errorf("Expected error for prefix length 40 of IPv4 route")
+	}
+}
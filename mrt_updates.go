@@ -0,0 +1,638 @@
+package routeimporter
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	MRT_TYPE_BGP4MP    = 16
+	MRT_TYPE_BGP4MP_ET = 17
+
+	MRT_SUBTYPE_BGP4MP_MESSAGE                   = 1
+	MRT_SUBTYPE_BGP4MP_MESSAGE_AS4               = 4
+	MRT_SUBTYPE_BGP4MP_MESSAGE_LOCAL             = 6
+	MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_LOCAL         = 7
+	MRT_SUBTYPE_BGP4MP_MESSAGE_ADDPATH           = 8
+	MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_ADDPATH       = 9
+	MRT_SUBTYPE_BGP4MP_MESSAGE_LOCAL_ADDPATH     = 10
+	MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_LOCAL_ADDPATH = 11
+
+	MRT_HEADER_LEN     = 12
+	BGP_MSG_HEADER_LEN = 19
+	BGP_MSG_UPDATE     = 2
+
+	BGP_ATTR_MP_UNREACH_NLRI = 15
+
+	BGP_AFI_IPV4     = 1
+	BGP_AFI_IPV6     = 2
+	BGP_SAFI_UNICAST = 1
+)
+
+// MrtUpdateImporter imports routes and their announce / withdraw timeline from
+// MRT BGP4MP / BGP4MP_ET update streams
+type MrtUpdateImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+	PeerV4      gosnappi.BgpV4Peer
+	PeerV6      gosnappi.BgpV6Peer
+}
+
+// bgpUpdate holds routes of one BGP UPDATE message
+type bgpUpdate struct {
+	PeerAddress net.IP
+	PeerAs      uint32
+	Internal    bool
+	Withdrawn   []Route
+	Announced   []Route
+}
+
+// String returns the id of the client.
+func (imp *MrtUpdateImporter) String() string {
+	return fmt.Sprintf("MRT Update Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for a BGP4MP / BGP4MP_ET record header at the start of the buffer
+func (imp *MrtUpdateImporter) Detect(buffer *[]byte) bool {
+	b := *buffer
+	if len(b) < MRT_HEADER_LEN {
+		return false
+	}
+	mrtType := binary.BigEndian.Uint16(b[4:6])
+	length := binary.BigEndian.Uint32(b[8:12])
+
+	return (mrtType == MRT_TYPE_BGP4MP || mrtType == MRT_TYPE_BGP4MP_ET) && int(length) <= len(b)-MRT_HEADER_LEN
+}
+
+// ImportRoutes adds one route range per announced route, with attributes of its
+// first announcement. IPv4 routes are added to the target v4 peer and IPv6 routes
+// to the target v6 peer.
+func (imp *MrtUpdateImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	if !ic.SplitInternal {
+		peerV4, peerV6, err := targetPeers(&ic)
+		if err != nil {
+			return nil, err
+		}
+		imp.PeerV4, imp.PeerV6 = peerV4, peerV6
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes returns each announced route once, with attributes of its first announcement
+func (imp *MrtUpdateImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	events, err := imp.ParseEvents(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []Route{}
+	found := map[string]bool{}
+	for _, event := range *events {
+		if event.Type != RouteEventAnnounce || found[event.Route.Name] {
+			continue
+		}
+		found[event.Route.Name] = true
+		routes = append(routes, event.Route)
+	}
+
+	return &routes, nil
+}
+
+// ParseEvents parses the update stream into a timeline of route events. All events
+// of a route announced by a peer share one route name; withdraws of routes never
+// announced in the stream have an empty route name. With target peers set, routes are
+// named as ImportRoutes names their route ranges, after existing route ranges matched
+// as per merge mode and renamed on collision with existing route range names.
+// Scale, Sample and WeightLocalPref would break the timeline and are rejected.
+func (imp *MrtUpdateImporter) ParseEvents(ic ImportConfig, buffer *[]byte) (*[]RouteEvent, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	if err := checkMrtUpdateConfig(&ic); err != nil {
+		return nil, err
+	}
+
+	if ic.NameTemplate != "" {
+		if err := validateNameTemplate(ic.NameTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	imp.startTask = time.Now()
+	events := []RouteEvent{}
+	names := map[string]string{}
+	// named as route ranges of the target peers, for route actions to match them
+	used := newMergeNames(&ic)
+	filter, err := newRouteFilter(&ic)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []FilteredRoute{}
+	var start time.Time
+	data := *buffer
+	for record := 0; len(data) > 0; record++ {
+		if len(data) < MRT_HEADER_LEN {
+			return nil, fmt.Errorf("truncated MRT header (record %d)", record+1)
+		}
+		timestamp := time.Unix(int64(binary.BigEndian.Uint32(data[0:4])), 0)
+		mrtType := binary.BigEndian.Uint16(data[4:6])
+		subtype := binary.BigEndian.Uint16(data[6:8])
+		length := binary.BigEndian.Uint32(data[8:12])
+		if uint64(len(data)-MRT_HEADER_LEN) < uint64(length) {
+			return nil, fmt.Errorf("truncated MRT record of %d bytes (record %d)", length, record+1)
+		}
+		message := data[MRT_HEADER_LEN : MRT_HEADER_LEN+length]
+		data = data[MRT_HEADER_LEN+length:]
+
+		if mrtType != MRT_TYPE_BGP4MP && mrtType != MRT_TYPE_BGP4MP_ET {
+			continue
+		}
+		if mrtType == MRT_TYPE_BGP4MP_ET {
+			if len(message) < 4 {
+				return nil, fmt.Errorf("truncated BGP4MP_ET record (record %d)", record+1)
+			}
+			timestamp = timestamp.Add(time.Duration(binary.BigEndian.Uint32(message[0:4])) * time.Microsecond)
+			message = message[4:]
+		}
+		as4, addPath, ok := bgp4mpMessageType(subtype)
+		if !ok {
+			// state changes
+			continue
+		}
+		update, err := parseBgp4mpMessage(message, as4, addPath, record)
+		if err != nil {
+			return nil, err
+		}
+		if update == nil {
+			// not an UPDATE message
+			continue
+		}
+		if start.IsZero() {
+			start = timestamp
+		}
+		offset := timestamp.Sub(start)
+
+		for _, batch := range []struct {
+			Type   RouteEventType
+			Routes []Route
+		}{
+			{RouteEventWithdraw, update.Withdrawn},
+			{RouteEventAnnounce, update.Announced},
+		} {
+			for _, route := range batch.Routes {
+				if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+					(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+					continue
+				}
+				key := fmt.Sprintf("%s|%s/%d|%d", update.PeerAddress, route.Network, route.PrefixLen, route.PathId)
+				if batch.Type == RouteEventAnnounce {
+					if reason := filter.check(&route); reason != "" {
+						filtered = append(filtered, FilteredRoute{Route: route, Reason: reason})
+						continue
+					}
+				}
+				name, ok := names[key]
+				if !ok && batch.Type == RouteEventAnnounce {
+					name = used.name(&route, routeName(&ic, &route, len(names)+1, SEQ_NAME_TEMPLATE))
+					names[key] = name
+				}
+				route.Name = name
+				events = append(events, RouteEvent{
+					Offset:      offset,
+					Type:        batch.Type,
+					PeerAddress: update.PeerAddress.String(),
+					PeerAs:      update.PeerAs,
+					Route:       route,
+				})
+			}
+		}
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Update parsing")
+	if ic.Report != nil {
+		ic.Report.Filtered = filtered
+	}
+
+	return &events, nil
+}
+
+// checkMrtUpdateConfig rejects import options of table imports, events of a route
+// are announced and withdrawn as one route range
+func checkMrtUpdateConfig(ic *ImportConfig) error {
+	switch {
+	case ic.Scale != nil && ic.Scale.Copies != 0:
+		return fmt.Errorf("cannot import - scale not supported for MRT update streams")
+	case ic.Sample != SampleNone:
+		return fmt.Errorf("cannot import - sample not supported for MRT update streams")
+	case ic.WeightLocalPref:
+		return fmt.Errorf("cannot import - weight local pref not supported for MRT update streams")
+	}
+
+	return nil
+}
+
+// RouteActions converts a route event timeline into route state changes of the
+// imported route ranges. Route ranges are advertised once the protocol starts, so
+// routes first announced after the start of the stream are withdrawn at offset 0.
+// Consecutive events of the same offset and state are merged into one action.
+func RouteActions(events *[]RouteEvent) *[]RouteAction {
+	actions := []RouteAction{}
+	if events == nil {
+		return &actions
+	}
+
+	initial := RouteAction{State: gosnappi.StateProtocolRouteState.WITHDRAW, Names: []string{}}
+	found := map[string]bool{}
+	for _, event := range *events {
+		if event.Route.Name == "" || found[event.Route.Name] {
+			continue
+		}
+		found[event.Route.Name] = true
+		if event.Offset > 0 {
+			initial.Names = append(initial.Names, event.Route.Name)
+		}
+	}
+	if len(initial.Names) > 0 {
+		actions = append(actions, initial)
+	}
+
+	started := map[string]bool{}
+	for _, event := range *events {
+		name := event.Route.Name
+		if name == "" {
+			continue
+		}
+		first := !started[name]
+		started[name] = true
+		if first && event.Offset == 0 {
+			// advertised on protocol start
+			continue
+		}
+		state := gosnappi.StateProtocolRouteState.ADVERTISE
+		if event.Type == RouteEventWithdraw {
+			state = gosnappi.StateProtocolRouteState.WITHDRAW
+		}
+		if last := len(actions) - 1; last >= 0 && actions[last].Offset == event.Offset && actions[last].State == state {
+			actions[last].Names = append(actions[last].Names, name)
+			continue
+		}
+		actions = append(actions, RouteAction{Offset: event.Offset, State: state, Names: []string{name}})
+	}
+
+	return &actions
+}
+
+// ControlState returns the OTG control state applying the action
+func (action RouteAction) ControlState() gosnappi.ControlState {
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames(action.Names).SetState(action.State)
+
+	return cs
+}
+
+// bgp4mpMessageType returns AS number size and add-path flag of BGP4MP message subtypes
+func bgp4mpMessageType(subtype uint16) (as4 bool, addPath bool, ok bool) {
+	switch subtype {
+	case MRT_SUBTYPE_BGP4MP_MESSAGE, MRT_SUBTYPE_BGP4MP_MESSAGE_LOCAL:
+		return false, false, true
+	case MRT_SUBTYPE_BGP4MP_MESSAGE_AS4, MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_LOCAL:
+		return true, false, true
+	case MRT_SUBTYPE_BGP4MP_MESSAGE_ADDPATH, MRT_SUBTYPE_BGP4MP_MESSAGE_LOCAL_ADDPATH:
+		return false, true, true
+	case MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_ADDPATH, MRT_SUBTYPE_BGP4MP_MESSAGE_AS4_LOCAL_ADDPATH:
+		return true, true, true
+	}
+
+	return false, false, false
+}
+
+// parseBgp4mpMessage parses peer and BGP message of a BGP4MP message record,
+// returns nil update for messages other than UPDATE
+func parseBgp4mpMessage(message []byte, as4 bool, addPath bool, record int) (*bgpUpdate, error) {
+	asLen := 2
+	if as4 {
+		asLen = 4
+	}
+	if len(message) < 2*asLen+4 {
+		return nil, fmt.Errorf("truncated BGP4MP message (record %d)", record+1)
+	}
+	update := &bgpUpdate{}
+	if as4 {
+		update.PeerAs = binary.BigEndian.Uint32(message[0:4])
+		update.Internal = update.PeerAs == binary.BigEndian.Uint32(message[4:8])
+	} else {
+		update.PeerAs = uint32(binary.BigEndian.Uint16(message[0:2]))
+		update.Internal = update.PeerAs == uint32(binary.BigEndian.Uint16(message[2:4]))
+	}
+	// skip interface index
+	afi := binary.BigEndian.Uint16(message[2*asLen+2 : 2*asLen+4])
+	message = message[2*asLen+4:]
+	ipLen := net.IPv4len
+	if afi == BGP_AFI_IPV6 {
+		ipLen = net.IPv6len
+	}
+	if len(message) < 2*ipLen+BGP_MSG_HEADER_LEN {
+		return nil, fmt.Errorf("truncated BGP4MP message (record %d)", record+1)
+	}
+	update.PeerAddress = net.IP(append([]byte{}, message[:ipLen]...))
+	message = message[2*ipLen:]
+
+	msgLen := int(binary.BigEndian.Uint16(message[16:18]))
+	if message[18] != BGP_MSG_UPDATE {
+		return nil, nil
+	}
+	if msgLen < BGP_MSG_HEADER_LEN || msgLen > len(message) {
+		return nil, fmt.Errorf("invalid BGP message length %d (record %d)", msgLen, record+1)
+	}
+	if err := parseBgpUpdate(update, message[BGP_MSG_HEADER_LEN:msgLen], as4, addPath); err != nil {
+		return nil, fmt.Errorf("%v (record %d)", err, record+1)
+	}
+	for i := range update.Withdrawn {
+		update.Withdrawn[i].Row = record
+		update.Withdrawn[i].Internal = update.Internal
+	}
+	for i := range update.Announced {
+		update.Announced[i].Row = record
+		update.Announced[i].Internal = update.Internal
+	}
+
+	return update, nil
+}
+
+// parseBgpUpdate parses withdrawn routes, path attributes and NLRI of an UPDATE message body
+func parseBgpUpdate(update *bgpUpdate, body []byte, as4 bool, addPath bool) error {
+	if len(body) < 2 {
+		return fmt.Errorf("truncated UPDATE message")
+	}
+	withdrawnLen := int(binary.BigEndian.Uint16(body[0:2]))
+	if len(body) < 4+withdrawnLen {
+		return fmt.Errorf("truncated UPDATE withdrawn routes")
+	}
+	withdrawn, err := parseNlri(body[2:2+withdrawnLen], false, addPath)
+	if err != nil {
+		return err
+	}
+	update.Withdrawn = append(update.Withdrawn, withdrawn...)
+	body = body[2+withdrawnLen:]
+	attrLen := int(binary.BigEndian.Uint16(body[0:2]))
+	if len(body) < 2+attrLen {
+		return fmt.Errorf("truncated UPDATE path attributes")
+	}
+	attrs := body[2 : 2+attrLen]
+	nlri := body[2+attrLen:]
+
+	template := Route{Best: true}
+	var mpNlri []Route
+	for len(attrs) > 0 {
+		if len(attrs) < 3 {
+			return fmt.Errorf("truncated path attribute")
+		}
+		flags, attrType := attrs[0], attrs[1]
+		hdrLen, valueLen := 3, int(attrs[2])
+		if flags&BGP_ATTR_FLAG_EXT_LENGTH != 0 {
+			if len(attrs) < 4 {
+				return fmt.Errorf("truncated path attribute")
+			}
+			hdrLen, valueLen = 4, int(binary.BigEndian.Uint16(attrs[2:4]))
+		}
+		if len(attrs) < hdrLen+valueLen {
+			return fmt.Errorf("truncated path attribute %d", attrType)
+		}
+		value := attrs[hdrLen : hdrLen+valueLen]
+		attrs = attrs[hdrLen+valueLen:]
+
+		switch attrType {
+		case BGP_ATTR_ORIGIN:
+			if len(value) != 1 {
+				return fmt.Errorf("invalid ORIGIN attribute")
+			}
+			switch value[0] {
+			case 0:
+				template.Origin = gosnappi.BgpRouteAdvancedOrigin.IGP
+			case 1:
+				template.Origin = gosnappi.BgpRouteAdvancedOrigin.EGP
+			}
+		case BGP_ATTR_AS_PATH:
+			segments, err := parseBgpAsPath(value, as4)
+			if err != nil {
+				return err
+			}
+			template.AsPath = segments
+		case BGP_ATTR_NEXT_HOP:
+			if len(value) != net.IPv4len {
+				return fmt.Errorf("invalid NEXT_HOP attribute")
+			}
+			template.NextHop = net.IP(value).String()
+		case BGP_ATTR_MED:
+			if len(value) != 4 {
+				return fmt.Errorf("invalid MULTI_EXIT_DISC attribute")
+			}
+			med := binary.BigEndian.Uint32(value)
+			template.Metric = &med
+		case BGP_ATTR_LOCAL_PREF:
+			if len(value) != 4 {
+				return fmt.Errorf("invalid LOCAL_PREF attribute")
+			}
+			locPrf := binary.BigEndian.Uint32(value)
+			template.LocalPref = &locPrf
+		case BGP_ATTR_COMMUNITIES:
+			if len(value)%4 != 0 {
+				return fmt.Errorf("invalid COMMUNITIES attribute")
+			}
+			for i := 0; i < len(value); i += 4 {
+				template.Communities = append(template.Communities, communityString(binary.BigEndian.Uint32(value[i:i+4])))
+			}
+		case BGP_ATTR_EXT_COMMUNITY:
+			rts, err := parseRouteTargets(value)
+			if err != nil {
+				return err
+			}
+			template.RouteTargets = rts
+		case BGP_ATTR_MP_REACH_NLRI:
+			if len(value) < 5 || len(value) < 5+int(value[3]) {
+				return fmt.Errorf("invalid MP_REACH_NLRI attribute")
+			}
+			afi, safi, nhLen := binary.BigEndian.Uint16(value[0:2]), value[2], int(value[3])
+			if safi != BGP_SAFI_UNICAST || (afi != BGP_AFI_IPV4 && afi != BGP_AFI_IPV6) {
+				log.Info().Msgf("MP_REACH_NLRI of AFI %d SAFI %d not supported", afi, safi)
+				continue
+			}
+			// global address of IPv6 next hops with link local address
+			switch {
+			case nhLen >= net.IPv6len:
+				template.NextHop = net.IP(value[4 : 4+net.IPv6len]).String()
+			case nhLen == net.IPv4len:
+				template.NextHop = net.IP(value[4 : 4+net.IPv4len]).String()
+			}
+			routes, err := parseNlri(value[5+nhLen:], afi == BGP_AFI_IPV6, addPath)
+			if err != nil {
+				return err
+			}
+			mpNlri = append(mpNlri, routes...)
+		case BGP_ATTR_MP_UNREACH_NLRI:
+			if len(value) < 3 {
+				return fmt.Errorf("invalid MP_UNREACH_NLRI attribute")
+			}
+			afi, safi := binary.BigEndian.Uint16(value[0:2]), value[2]
+			if safi != BGP_SAFI_UNICAST || (afi != BGP_AFI_IPV4 && afi != BGP_AFI_IPV6) {
+				log.Info().Msgf("MP_UNREACH_NLRI of AFI %d SAFI %d not supported", afi, safi)
+				continue
+			}
+			routes, err := parseNlri(value[3:], afi == BGP_AFI_IPV6, addPath)
+			if err != nil {
+				return err
+			}
+			update.Withdrawn = append(update.Withdrawn, routes...)
+		}
+	}
+
+	announced, err := parseNlri(nlri, false, addPath)
+	if err != nil {
+		return err
+	}
+	for _, route := range append(announced, mpNlri...) {
+		network, prefixLen, pathId := route.Network, route.PrefixLen, route.PathId
+		route = template
+		route.Network, route.PrefixLen, route.PathId = network, prefixLen, pathId
+		update.Announced = append(update.Announced, route)
+	}
+
+	return nil
+}
+
+// parseNlri parses prefixes of NLRI / withdrawn routes fields
+func parseNlri(data []byte, v6 bool, addPath bool) ([]Route, error) {
+	routes := []Route{}
+	maxLen := 32
+	if v6 {
+		maxLen = 128
+	}
+	for len(data) > 0 {
+		route := Route{}
+		if addPath {
+			if len(data) < 4 {
+				return nil, fmt.Errorf("truncated NLRI path id")
+			}
+			route.PathId = binary.BigEndian.Uint32(data[0:4])
+			data = data[4:]
+		}
+		if len(data) < 1 || int(data[0]) > maxLen {
+			return nil, fmt.Errorf("invalid NLRI prefix length")
+		}
+		prefixLen := int(data[0])
+		size := (prefixLen + 7) / 8
+		if len(data) < 1+size {
+			return nil, fmt.Errorf("truncated NLRI prefix")
+		}
+		network := make(net.IP, maxLen/8)
+		copy(network, data[1:1+size])
+		route.Network = network.Mask(net.CIDRMask(prefixLen, maxLen))
+		route.PrefixLen = prefixLen
+		routes = append(routes, route)
+		data = data[1+size:]
+	}
+
+	return routes, nil
+}
+
+// parseBgpAsPath parses AS_PATH attribute with 2 or 4 byte AS numbers
+func parseBgpAsPath(value []byte, as4 bool) ([]AsPathSegment, error) {
+	asLen := 2
+	if as4 {
+		asLen = 4
+	}
+	segments := []AsPathSegment{}
+	for len(value) > 0 {
+		if len(value) < 2 || len(value) < 2+int(value[1])*asLen {
+			return nil, fmt.Errorf("truncated AS_PATH attribute")
+		}
+		seg := AsPathSegment{Type: gosnappi.BgpAsPathSegmentType.AS_SEQ}
+		switch value[0] {
+		case 1:
+			seg.Type = gosnappi.BgpAsPathSegmentType.AS_SET
+		case 3:
+			seg.Type = gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ
+		case 4:
+			seg.Type = gosnappi.BgpAsPathSegmentType.AS_CONFED_SET
+		}
+		count := int(value[1])
+		for i := 0; i < count; i++ {
+			if as4 {
+				seg.AsNumbers = append(seg.AsNumbers, binary.BigEndian.Uint32(value[2+i*4:]))
+			} else {
+				seg.AsNumbers = append(seg.AsNumbers, uint32(binary.BigEndian.Uint16(value[2+i*2:])))
+			}
+		}
+		segments = append(segments, seg)
+		value = value[2+count*asLen:]
+	}
+
+	return segments, nil
+}
+
+// parseRouteTargets returns route targets found in EXTENDED_COMMUNITIES attribute
+func parseRouteTargets(value []byte) ([]string, error) {
+	if len(value)%8 != 0 {
+		return nil, fmt.Errorf("invalid EXTENDED_COMMUNITIES attribute")
+	}
+	rts := []string{}
+	for ; len(value) > 0; value = value[8:] {
+		if value[1] != BGP_EXT_COMMUNITY_SUBTYPE_ROUTE_TARGET {
+			continue
+		}
+		var rtType gosnappi.BgpExtCommunityTypeEnum
+		switch value[0] {
+		case 0x00:
+			rtType = gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_2OCTET
+		case 0x01:
+			rtType = gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS
+		case 0x02:
+			rtType = gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET
+		default:
+			continue
+		}
+		rt, err := routeTargetString(rtType, hex.EncodeToString(value[2:8]))
+		if err != nil {
+			return nil, err
+		}
+		rts = append(rts, rt)
+	}
+
+	return rts, nil
+}
@@ -0,0 +1,251 @@
+package routeimporter_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type EventEntry struct {
+	Offset  time.Duration
+	Type    routeimporter.RouteEventType
+	Network string
+	Name    string
+}
+
+// bgp4mpEtRecord encodes a BGP4MP_ET MESSAGE_AS4 record of peer 10.0.0.2 AS 65002
+func bgp4mpEtRecord(secs uint32, usecs uint32, subtype uint16, withdrawn []byte, attrs []byte, nlri []byte) []byte {
+	update := binary.BigEndian.AppendUint16(nil, uint16(len(withdrawn)))
+	update = append(update, withdrawn...)
+	update = binary.BigEndian.AppendUint16(update, uint16(len(attrs)))
+	update = append(update, attrs...)
+	update = append(update, nlri...)
+
+	msg := binary.BigEndian.AppendUint32(nil, usecs)
+	msg = binary.BigEndian.AppendUint32(msg, 65002)
+	msg = binary.BigEndian.AppendUint32(msg, 65001)
+	msg = binary.BigEndian.AppendUint16(msg, 0)
+	msg = binary.BigEndian.AppendUint16(msg, 1)
+	msg = append(msg, net.ParseIP("10.0.0.2").To4()...)
+	msg = append(msg, net.ParseIP("10.0.0.1").To4()...)
+	for i := 0; i < 16; i++ {
+		msg = append(msg, 0xff)
+	}
+	msg = binary.BigEndian.AppendUint16(msg, uint16(19+len(update)))
+	msg = append(msg, 2)
+	msg = append(msg, update...)
+
+	record := binary.BigEndian.AppendUint32(nil, secs)
+	record = binary.BigEndian.AppendUint16(record, 17)
+	record = binary.BigEndian.AppendUint16(record, subtype)
+	record = binary.BigEndian.AppendUint32(record, uint32(len(msg)))
+	return append(record, msg...)
+}
+
+func TestImportMrtUpdates(t *testing.T) {
+	// ORIGIN IGP, AS_PATH 65002 15169, NEXT_HOP 10.0.0.2, MED 10
+	attrs := []byte{
+		0x40, 1, 1, 0,
+		0x40, 2, 10, 2, 2, 0, 0, 0xfd, 0xea, 0, 0, 0x3b, 0x41,
+		0x40, 3, 4, 10, 0, 0, 2,
+		0x80, 4, 4, 0, 0, 0, 10,
+	}
+	// ORIGIN IGP, AS_PATH 65002 3356 15169, NEXT_HOP 10.0.0.2
+	attrsChanged := []byte{
+		0x40, 1, 1, 0,
+		0x40, 2, 14, 2, 3, 0, 0, 0xfd, 0xea, 0, 0, 0x0d, 0x1c, 0, 0, 0x3b, 0x41,
+		0x40, 3, 4, 10, 0, 0, 2,
+	}
+	// ORIGIN IGP, MP_REACH_NLRI 2001:db8::/32 via 2001:db8::2
+	attrsV6 := []byte{
+		0x40, 1, 1, 0,
+		0x80, 14, 26, 0, 2, 1, 16,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		0, 32, 0x20, 0x01, 0x0d, 0xb8,
+	}
+	// MP_UNREACH_NLRI 2001:db8::/32
+	attrsV6Withdraw := []byte{0x80, 15, 8, 0, 2, 1, 32, 0x20, 0x01, 0x0d, 0xb8}
+
+	stream := bgp4mpEtRecord(1000, 0, 4, nil, attrs, []byte{24, 1, 0, 0, 24, 1, 0, 1})
+	stream = append(stream, bgp4mpEtRecord(1000, 500000, 4, nil, attrsV6, nil)...)
+	stream = append(stream, bgp4mpEtRecord(1005, 0, 4, []byte{24, 1, 0, 0, 24, 9, 9, 9}, nil, nil)...)
+	stream = append(stream, bgp4mpEtRecord(1010, 250000, 4, nil, attrsChanged, []byte{24, 1, 0, 0})...)
+	// state change record is skipped
+	stream = append(stream, []byte{0, 0, 0x03, 0xf3, 0, 17, 0, 5, 0, 0, 0, 4, 0, 0, 0, 0}...)
+	stream = append(stream, bgp4mpEtRecord(1012, 0, 4, nil, append(attrsV6Withdraw, attrs...), []byte{24, 1, 0, 2})...)
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeMrtUpdates)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "mrt",
+		RetainNexthop: true,
+	}
+	events, err := is.(*routeimporter.MrtUpdateImporter).ParseEvents(ic, &stream)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse events. error: %v", err))
+		return
+	}
+
+	announce, withdraw := routeimporter.RouteEventAnnounce, routeimporter.RouteEventWithdraw
+	expEvents := []EventEntry{
+		{0, announce, "1.0.0.0/24", "mrt-1"},
+		{0, announce, "1.0.1.0/24", "mrt-2"},
+		{500 * time.Millisecond, announce, "2001:db8::/32", "mrt-3"},
+		{5 * time.Second, withdraw, "1.0.0.0/24", "mrt-1"},
+		{5 * time.Second, withdraw, "9.9.9.0/24", ""},
+		{10250 * time.Millisecond, announce, "1.0.0.0/24", "mrt-1"},
+		{12 * time.Second, withdraw, "2001:db8::/32", "mrt-3"},
+		{12 * time.Second, announce, "1.0.2.0/24", "mrt-4"},
+	}
+	if len(*events) != len(expEvents) {
+		t.Errorf("Expected %d events, found %d", len(expEvents), len(*events))
+		return
+	}
+	for i, exp := range expEvents {
+		event := (*events)[i]
+		network := fmt.Sprintf("%s/%d", event.Route.Network, event.Route.PrefixLen)
+		if event.Offset != exp.Offset || event.Type != exp.Type || network != exp.Network || event.Route.Name != exp.Name {
+			t.Errorf("Event %d mismatch. Expected %v, found {%v %v %s %s}", i, exp,
+				event.Offset, event.Type, network, event.Route.Name)
+		}
+	}
+	first := (*events)[0]
+	if first.PeerAddress != "10.0.0.2" || first.PeerAs != 65002 || first.Route.NextHop != "10.0.0.2" ||
+		first.Route.Metric == nil || *first.Route.Metric != 10 ||
+		!reflect.DeepEqual(first.Route.AsPath[0].AsNumbers, []uint32{65002, 15169}) {
+		t.Errorf("Unexpected attributes of first event: %+v", first)
+	}
+	if (*events)[2].Route.NextHop != "2001:db8::2" {
+		t.Errorf("Expected next hop 2001:db8::2, found %s", (*events)[2].Route.NextHop)
+	}
+
+	expActions := []routeimporter.RouteAction{
+		{Offset: 0, State: gosnappi.StateProtocolRouteState.WITHDRAW, Names: []string{"mrt-3", "mrt-4"}},
+		{Offset: 500 * time.Millisecond, State: gosnappi.StateProtocolRouteState.ADVERTISE, Names: []string{"mrt-3"}},
+		{Offset: 5 * time.Second, State: gosnappi.StateProtocolRouteState.WITHDRAW, Names: []string{"mrt-1"}},
+		{Offset: 10250 * time.Millisecond, State: gosnappi.StateProtocolRouteState.ADVERTISE, Names: []string{"mrt-1"}},
+		{Offset: 12 * time.Second, State: gosnappi.StateProtocolRouteState.WITHDRAW, Names: []string{"mrt-3"}},
+		{Offset: 12 * time.Second, State: gosnappi.StateProtocolRouteState.ADVERTISE, Names: []string{"mrt-4"}},
+	}
+	actions := routeimporter.RouteActions(events)
+	if !reflect.DeepEqual(*actions, expActions) {
+		t.Errorf("Expected actions %v, found %v", expActions, *actions)
+	}
+	cs := (*actions)[0].ControlState()
+	if !reflect.DeepEqual(cs.Protocol().Route().Names(), []string{"mrt-3", "mrt-4"}) {
+		t.Errorf("Unexpected control state %v", cs)
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("10.0.0.2").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65002)
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peer}
+	names, err := is.ImportRoutes(ic, &stream)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	// IPv6 route is skipped without target v6 peer
+	if !reflect.DeepEqual(*names, []string{"mrt-1", "mrt-2", "mrt-4"}) {
+		t.Errorf("Unexpected imported route names %v", *names)
+	}
+	if len(peer.V4Routes().Items()) != 3 {
+		t.Errorf("Expected 3 route ranges, found %d", len(peer.V4Routes().Items()))
+	}
+	if _, err := config.ToJson(); err != nil {
+		t.Errorf("failed to convert config in Json format. Error: %v", err.Error())
+	}
+}
+
+func TestMrtUpdateEventNamesOfExistingRanges(t *testing.T) {
+	// ORIGIN IGP, AS_PATH 65002, NEXT_HOP 10.0.0.2
+	attrs := []byte{
+		0x40, 1, 1, 0,
+		0x40, 2, 6, 2, 1, 0, 0, 0xfd, 0xea,
+		0x40, 3, 4, 10, 0, 0, 2,
+	}
+	stream := bgp4mpEtRecord(1000, 0, 4, nil, attrs, []byte{24, 1, 0, 0, 24, 1, 0, 1})
+	stream = append(stream, bgp4mpEtRecord(1002, 0, 4, nil, attrs, []byte{24, 1, 0, 2})...)
+
+	for _, mode := range []routeimporter.MergeMode{routeimporter.MergeModeAppend, routeimporter.MergeModeUpsert} {
+		config := gosnappi.NewConfig()
+		peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+			Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+		peer.SetPeerAddress("10.0.0.2").SetAsType(gosnappi.BgpV4PeerAsType.EBGP).SetAsNumber(65002)
+		// existing range named as the first route, with the prefix of the second route
+		peer.V4Routes().Add().SetName("mrt-1").Addresses().Add().SetAddress("1.0.1.0").SetPrefix(24)
+
+		is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeMrtUpdates)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+			return
+		}
+		ic := routeimporter.ImportConfig{NamePrefix: "mrt", MergeMode: mode, Targetv4Peers: []gosnappi.BgpV4Peer{peer}}
+		events, err := is.(*routeimporter.MrtUpdateImporter).ParseEvents(ic, &stream)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse events. error: %v", err))
+			return
+		}
+		names, err := is.ImportRoutes(ic, &stream)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+		eventNames := []string{}
+		for _, event := range *events {
+			eventNames = append(eventNames, event.Route.Name)
+		}
+		if !reflect.DeepEqual(eventNames, *names) {
+			t.Errorf("Event names %v do not match route range names %v, merge mode %v", eventNames, *names, mode)
+		}
+		ranges := map[string]bool{}
+		for _, rr := range peer.V4Routes().Items() {
+			ranges[rr.Name()] = true
+		}
+		for _, action := range *routeimporter.RouteActions(events) {
+			for _, name := range action.Names {
+				if !ranges[name] {
+					t.Errorf("Route action of unknown route range %s, merge mode %v", name, mode)
+				}
+			}
+		}
+	}
+}
+
+func TestImportMrtUpdatesTableOptions(t *testing.T) {
+	// ORIGIN IGP, AS_PATH 65002, NEXT_HOP 10.0.0.2
+	attrs := []byte{
+		0x40, 1, 1, 0,
+		0x40, 2, 6, 2, 1, 0, 0, 0xfd, 0xea,
+		0x40, 3, 4, 10, 0, 0, 2,
+	}
+	stream := bgp4mpEtRecord(1000, 0, 4, nil, attrs, []byte{24, 1, 0, 0, 24, 1, 0, 1})
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeMrtUpdates)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	for _, ic := range []routeimporter.ImportConfig{
+		{NamePrefix: "mrt", Scale: &routeimporter.ScaleConfig{Copies: 1}},
+		{NamePrefix: "mrt", Sample: routeimporter.SampleFirst, SampleSize: 1},
+		{NamePrefix: "mrt", WeightLocalPref: true},
+	} {
+		if _, err := is.(*routeimporter.MrtUpdateImporter).ParseEvents(ic, &stream); err == nil {
+			t.Errorf("Expected error parsing events with %+v", ic)
+		}
+		if _, err := is.ImportRoutes(ic, &stream); err == nil {
+			t.Errorf("Expected error importing routes with %+v", ic)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package routeimporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DEFAULT_NAME_TEMPLATE names routes by import file row
+	DEFAULT_NAME_TEMPLATE = "{prefix}-{row}"
+	// SEQ_NAME_TEMPLATE names routes by sequence, for imports without rows
+	SEQ_NAME_TEMPLATE = "{prefix}-{seq}"
+)
+
+var nameFieldPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// routeNameFields returns value of each name template field for the route,
+// seq is the 1 based sequence of the route in the import
+func routeNameFields(ic *ImportConfig, route *Route, seq int) map[string]string {
+	originField := "local"
+	if as, _, ok := originAs(route); ok {
+		originField = fmt.Sprint(as)
+	}
+	network := ""
+	if route.Network != nil {
+		network = route.Network.String()
+	}
+
+	return map[string]string{
+		"{prefix}":    ic.NamePrefix,
+		"{row}":       fmt.Sprint(route.Row + 1),
+		"{seq}":       fmt.Sprint(seq),
+		"{network}":   network,
+		"{len}":       fmt.Sprint(route.PrefixLen),
+		"{origin_as}": originField,
+		"{nexthop}":   route.NextHop,
+		"{rd}":        route.Rd,
+		"{vrf}":       route.Vrf,
+		"{path_id}":   fmt.Sprint(route.PathId),
+		"{copy}":      fmt.Sprint(route.Copy),
+	}
+}
+
+// validateNameTemplate checks that all fields of the name template are known
+func validateNameTemplate(template string) error {
+	fields := routeNameFields(&ImportConfig{}, &Route{}, 0)
+	for _, field := range nameFieldPattern.FindAllString(template, -1) {
+		if _, ok := fields[field]; !ok {
+			return fmt.Errorf("unknown field %s in name template %q", field, template)
+		}
+	}
+
+	return nil
+}
+
+// routeName returns name of the route as per name template of the import config,
+// or fallback template if not set
+func routeName(ic *ImportConfig, route *Route, seq int, fallback string) string {
+	template := ic.NameTemplate
+	if template == "" {
+		template = fallback
+	}
+	fields := routeNameFields(ic, route, seq)
+	name := nameFieldPattern.ReplaceAllStringFunc(template, func(field string) string {
+		return fields[field]
+	})
+	if route.Copy > 0 && !strings.Contains(template, "{copy}") {
+		name = fmt.Sprintf("%s-c%d", name, route.Copy)
+	}
+
+	return name
+}
+
+// nameRoutes names parsed routes in order as per name template of the import config,
+// or fallback template if not set. Names repeated by the template are made unique.
+func nameRoutes(ic *ImportConfig, routes []Route, fallback string) error {
+	template := ic.NameTemplate
+	if template == "" {
+		template = fallback
+	}
+	if err := validateNameTemplate(template); err != nil {
+		return err
+	}
+	names := newNameSet(nil)
+	for i := range routes {
+		routes[i].Name = names.unique(routeName(ic, &routes[i], i+1, template))
+	}
+
+	return nil
+}
+
+// nameSet tracks used route names
+type nameSet struct {
+	used map[string]bool
+	// smallest "-<n>" suffix possibly free, per renamed name
+	next map[string]int
+}
+
+func newNameSet(names []string) *nameSet {
+	set := &nameSet{used: map[string]bool{}, next: map[string]int{}}
+	for _, name := range names {
+		set.used[name] = true
+	}
+
+	return set
+}
+
+// has checks if the name is used
+func (set *nameSet) has(name string) bool {
+	return set.used[name]
+}
+
+// unique returns the name, or the name with smallest free "-<n>" suffix if already
+// used, and marks it as used
+func (set *nameSet) unique(name string) string {
+	if set.used[name] {
+		base := name
+		n := set.next[base]
+		if n == 0 {
+			n = 2
+		}
+		for name = fmt.Sprintf("%s-%d", base, n); set.used[name]; name = fmt.Sprintf("%s-%d", base, n) {
+			n++
+		}
+		set.next[base] = n + 1
+		log.Info().Msgf("route name %s already used, renamed to %s", base, name)
+	}
+	set.used[name] = true
+
+	return name
+}
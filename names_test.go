@@ -0,0 +1,105 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesNameTemplate(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	tests := []struct {
+		Template string
+		Names    []string
+	}{
+		{"", []string{"n-8", "n-9", "n-10", "n-11", "n-12", "n-13"}},
+		{"{prefix}-{seq}", []string{"n-1", "n-2", "n-3", "n-4", "n-5", "n-6"}},
+		{"{prefix}-{network}-{len}", []string{
+			"n-1.0.0.0-24", "n-1.0.0.0-24-2", "n-1.0.0.0-24-3", "n-1.0.0.0-24-4", "n-1.0.5.0-24", "n-1.0.5.0-24-2"}},
+		{"{prefix}-{origin_as}-{row}", []string{
+			"n-15169-8", "n-15169-9", "n-15169-10", "n-56203-11", "n-56203-12", "n-56203-13"}},
+	}
+	for _, test := range tests {
+		config := gosnappi.NewConfig()
+		peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+			Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+		peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+		ic := routeimporter.ImportConfig{
+			NamePrefix:    "n",
+			NameTemplate:  test.Template,
+			RRType:        routeimporter.RouteTypeIpv4,
+			Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		}
+		is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+			return
+		}
+		names, err := is.ImportRoutes(ic, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+		if !reflect.DeepEqual(*names, test.Names) {
+			t.Errorf("Template %q: expected names %v, found %v", test.Template, test.Names, *names)
+		}
+		// returned names match route range names
+		for i, rr := range peer.V4Routes().Items() {
+			if i < len(*names) && rr.Name() != (*names)[i] {
+				t.Errorf("Template %q: route range %s returned as %s", test.Template, rr.Name(), (*names)[i])
+			}
+		}
+	}
+
+	is, _ := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	ic := routeimporter.ImportConfig{NamePrefix: "n", NameTemplate: "{prefix}-{unknown}"}
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for unknown name template field")
+	}
+}
+
+func TestParseRoutesNameTemplateCollisions(t *testing.T) {
+	var b strings.Builder
+	count := 20000
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(&b, "10.%d.%d.0/24,192.0.2.1,65001 65002\n", i/256, i%256)
+	}
+	fb := []byte(b.String())
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCsv)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	// template colliding for every route
+	ic := routeimporter.ImportConfig{NamePrefix: "n", NameTemplate: "{prefix}-{origin_as}"}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != count {
+		t.Errorf("Expected %d routes, found %d", count, len(*routes))
+		return
+	}
+	names := map[string]bool{}
+	for _, route := range *routes {
+		names[route.Name] = true
+	}
+	first, second, last := (*routes)[0].Name, (*routes)[1].Name, (*routes)[count-1].Name
+	if len(names) != count || first != "n-65002" || second != "n-65002-2" || last != fmt.Sprintf("n-65002-%d", count) {
+		t.Errorf("Unexpected route names %s, %s, %s, %d unique", first, second, last, len(names))
+	}
+}
@@ -0,0 +1,128 @@
+package routeimporter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	DETECT_MAX_LINES = 200 // lines of the import buffer looked at by format detection
+)
+
+// importerRegistry holds import service factories by name, in registration order
+type importerRegistry struct {
+	lock      sync.RWMutex
+	factories map[string]func() ImportService
+	names     []string
+}
+
+var importers = importerRegistry{factories: map[string]func() ImportService{}}
+
+func init() {
+	// generic formats first, as detection asks them last
+	RegisterImporter("csv", builtinFactory(newCsvImporter))
+	RegisterImporter("route-list", builtinFactory(newRouteListImporter))
+	RegisterImporter("cisco", builtinFactory(newCiscoImporter))
+	RegisterImporter("cisco-rib", builtinFactory(newRibImporter))
+	RegisterImporter("evpn", builtinFactory(newEvpnImporter))
+	RegisterImporter("mrt-updates", builtinFactory(newMrtUpdateImporter))
+	RegisterImporter("bird", builtinFactory(newBirdImporter))
+	RegisterImporter("gobgp", builtinFactory(newGobgpImporter))
+	RegisterImporter("exabgp", builtinFactory(newExabgpImporter))
+}
+
+// builtinFactory adapts the constructor of a built-in import service to an importer factory
+func builtinFactory(newImporter func() (ImportService, error)) func() ImportService {
+	return func() ImportService {
+		is, _ := newImporter()
+		return is
+	}
+}
+
+// RegisterImporter makes an import service available by name to GetImporterServiceByName
+// and, if the import service implements FormatDetector, to DetectImporterService.
+// It is meant to be called from init functions and panics if name is empty or already
+// registered, or if factory is nil.
+func RegisterImporter(name string, factory func() ImportService) {
+	importers.lock.Lock()
+	defer importers.lock.Unlock()
+	if name == "" {
+		panic("routeimporter: RegisterImporter with empty name")
+	}
+	if factory == nil {
+		panic("routeimporter: RegisterImporter factory is nil for " + name)
+	}
+	if _, ok := importers.factories[name]; ok {
+		panic("routeimporter: RegisterImporter called twice for " + name)
+	}
+	importers.factories[name] = factory
+	importers.names = append(importers.names, name)
+}
+
+// ImporterNames returns the sorted names of registered import services
+func ImporterNames() []string {
+	importers.lock.RLock()
+	defer importers.lock.RUnlock()
+	names := append([]string{}, importers.names...)
+	sort.Strings(names)
+
+	return names
+}
+
+// GetImporterServiceByName returns a new import service registered under name
+func GetImporterServiceByName(name string) (ImportService, error) {
+	importers.lock.RLock()
+	factory, ok := importers.factories[name]
+	importers.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown importer %q, registered importers: %s", name,
+			strings.Join(ImporterNames(), ", "))
+	}
+
+	return factory(), nil
+}
+
+// DetectImporterService returns a new import service of the format detected in the buffer,
+// along with its registered name. Import services are asked in reverse registration order,
+// so importers registered by other packages take precedence over the built-in ones.
+func DetectImporterService(buffer *[]byte) (ImportService, string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, "", fmt.Errorf("cannot detect format - empty route buffer")
+	}
+	importers.lock.RLock()
+	names := append([]string{}, importers.names...)
+	importers.lock.RUnlock()
+
+	for i := len(names) - 1; i >= 0; i-- {
+		is, err := GetImporterServiceByName(names[i])
+		if err != nil {
+			return nil, "", err
+		}
+		if detector, ok := is.(FormatDetector); ok && detector.Detect(buffer) {
+			return is, names[i], nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("cannot detect format of route buffer, registered importers: %s",
+		strings.Join(ImporterNames(), ", "))
+}
+
+// detectLines returns the leading lines of the buffer looked at by format detection
+func detectLines(buffer *[]byte) []string {
+	lines := []string{}
+	rest := *buffer
+	for len(rest) > 0 && len(lines) < DETECT_MAX_LINES {
+		line := rest
+		if end := bytes.IndexByte(rest, '\n'); end != -1 {
+			line, rest = rest[:end], rest[end+1:]
+		} else {
+			rest = nil
+		}
+		lines = append(lines, strings.TrimRight(string(line), "\r"))
+	}
+
+	return lines
+}
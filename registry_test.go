@@ -0,0 +1,106 @@
+package routeimporter_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+)
+
+// prefixListImporter parses "prefix-list" dumps of one prefix per line
+type prefixListImporter struct{}
+
+func (imp *prefixListImporter) String() string {
+	return "Prefix List Route Importer"
+}
+
+func (imp *prefixListImporter) Detect(buffer *[]byte) bool {
+	return bytes.HasPrefix(*buffer, []byte("prefix-list"))
+}
+
+func (imp *prefixListImporter) ImportRoutes(ic routeimporter.ImportConfig, buffer *[]byte) (*[]string, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (imp *prefixListImporter) ParseRoutes(ic routeimporter.ImportConfig, buffer *[]byte) (*[]routeimporter.Route, error) {
+	routes := []routeimporter.Route{}
+	for _, line := range strings.Split(string(*buffer), "\n")[1:] {
+		if line != "" {
+			routes = append(routes, routeimporter.Route{Name: line})
+		}
+	}
+	return &routes, nil
+}
+
+func init() {
+	routeimporter.RegisterImporter("prefix-list", func() routeimporter.ImportService {
+		return &prefixListImporter{}
+	})
+}
+
+func TestImporterRegistry(t *testing.T) {
+	names := routeimporter.ImporterNames()
+	registered := map[string]bool{}
+	for _, name := range names {
+		registered[name] = true
+	}
+	if !sort.StringsAreSorted(names) || !registered["cisco"] || !registered["mrt-updates"] || !registered["prefix-list"] {
+		t.Errorf("Unexpected registered importers %v", names)
+	}
+
+	is, err := routeimporter.GetImporterServiceByName("prefix-list")
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	fb := []byte("prefix-list\n1.0.0.0/24\n1.0.4.0/22\n")
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{}, &fb)
+	if err != nil || len(*routes) != 2 {
+		t.Errorf("Expected 2 routes from registered importer, error: %v", err)
+	}
+	if _, err := routeimporter.GetImporterServiceByName("juniper"); err == nil {
+		t.Errorf("Expected error for unknown importer")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for importer registered twice")
+		}
+	}()
+	routeimporter.RegisterImporter("cisco", func() routeimporter.ImportService { return &prefixListImporter{} })
+}
+
+func TestDetectImporterService(t *testing.T) {
+	tests := map[string]string{
+		"resource/cisco_v4_basic.txt":    "cisco",
+		"resource/cisco_vpnv4_basic.txt": "cisco",
+		"resource/cisco_rib_v4.txt":      "cisco-rib",
+		"resource/cisco_rib_v6.txt":      "cisco-rib",
+		"resource/arista_evpn_basic.txt": "evpn",
+		"resource/nxos_evpn_basic.txt":   "evpn",
+		"resource/cisco_v4_internal.txt": "cisco",
+	}
+	for filename, expected := range tests {
+		fb, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+			continue
+		}
+		if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != expected {
+			t.Errorf("%s: expected format %s, found %q, error: %v", filename, expected, name, err)
+		}
+	}
+
+	fb := []byte("prefix-list\n1.0.0.0/24\n")
+	if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "prefix-list" {
+		t.Errorf("Expected registered importer detected, found %q, error: %v", name, err)
+	}
+	fb = []byte("no route table here\n")
+	if _, _, err := routeimporter.DetectImporterService(&fb); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,316 @@
+package routeimporter
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RIB_CODES_PREFIX     = "Codes:"
+	RIB_GATEWAY_PREFIX   = "Gateway of last resort"
+	RIB_SUBNETTED_SUFFIX = "is subnetted"
+	RIB_VIA              = "via"
+	RIB_CONNECTED        = "directly connected"
+	RIB_CANDIDATE_MARKER = "*"
+
+	RIB_PROTOCOL_ISIS = "i"
+	RIB_PROTOCOL_OSPF = "O"
+)
+
+// ribSubCodes lists second code tokens of show ip route entries (e.g. "O IA", "i L2")
+var ribSubCodes = map[string]bool{
+	"IA": true, "E1": true, "E2": true, "N1": true, "N2": true,
+	"L1": true, "L2": true, "ia": true, "su": true, "EX": true,
+}
+
+// ribV6Codes maps show ipv6 route codes to their show ip route equivalent
+var ribV6Codes = map[string]string{
+	"I1":  "i L1",
+	"I2":  "i L2",
+	"IA":  "i ia",
+	"IS":  "i su",
+	"OI":  "O IA",
+	"OE1": "O E1",
+	"OE2": "O E2",
+	"ON1": "O N1",
+	"ON2": "O N2",
+	"EX":  "D EX",
+}
+
+// RibImporter imports routes from Cisco show ip route / show ipv6 route output
+type RibImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+	lines       []string
+	IsisRouter  gosnappi.DeviceIsisRouter
+}
+
+// String returns the id of the client.
+func (imp *RibImporter) String() string {
+	return fmt.Sprintf("Cisco RIB Route Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for the codes legend or gateway of last resort line of show ip route output
+func (imp *RibImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		if strings.HasPrefix(line, RIB_CODES_PREFIX) || strings.HasPrefix(line, RIB_GATEWAY_PREFIX) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportRoutes adds IS-IS routes, and routes of other protocols selected by RibProtocols,
+// to the target IS-IS router as per merge mode. OSPF routes are not imported.
+func (imp *RibImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	merger, err := newIsisTargetMerger(&ic)
+	if err != nil {
+		return nil, err
+	}
+	imp.IsisRouter = ic.TargetIsisRouters[0]
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	for i := range *routes {
+		route := &(*routes)[i]
+		base := strings.Fields(route.Protocol)[0]
+		if base == RIB_PROTOCOL_OSPF {
+			// no OSPFv2 route range in gosnappi
+			log.Info().Msgf("OSPF route %s/%d not supported for router update (line %d)",
+				route.Network, route.PrefixLen, route.Row+1)
+			continue
+		}
+		if base != RIB_PROTOCOL_ISIS && !isSelectedProtocol(ic.RibProtocols, route.Protocol, true) {
+			// non IS-IS routes are redistributed only if explicitly selected
+			continue
+		}
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Router update")
+
+	return &route_names, nil
+}
+
+// newIsisV4RouteRange creates an IS-IS v4 route range from the parsed RIB route, internal
+// for IS-IS routes and external for redistributed routes
+func newIsisV4RouteRange(route *Route) gosnappi.IsisV4RouteRange {
+	rr := gosnappi.NewIsisV4RouteRange().SetName(route.Name)
+	rr.Addresses().Add().SetAddress(route.Network.String()).SetPrefix(uint32(route.PrefixLen))
+	if route.Metric != nil {
+		rr.SetLinkMetric(*route.Metric)
+	}
+	if strings.Fields(route.Protocol)[0] == RIB_PROTOCOL_ISIS {
+		rr.SetOriginType(gosnappi.IsisV4RouteRangeOriginType.INTERNAL)
+	} else {
+		rr.SetOriginType(gosnappi.IsisV4RouteRangeOriginType.EXTERNAL)
+	}
+	if route.Protocol == "i ia" {
+		rr.SetRedistributionType(gosnappi.IsisV4RouteRangeRedistributionType.DOWN)
+	}
+
+	return rr
+}
+
+// newIsisV6RouteRange creates an IS-IS v6 route range from the parsed RIB route, internal
+// for IS-IS routes and external for redistributed routes
+func newIsisV6RouteRange(route *Route) gosnappi.IsisV6RouteRange {
+	rr := gosnappi.NewIsisV6RouteRange().SetName(route.Name)
+	rr.Addresses().Add().SetAddress(route.Network.String()).SetPrefix(uint32(route.PrefixLen))
+	if route.Metric != nil {
+		rr.SetLinkMetric(*route.Metric)
+	}
+	if strings.Fields(route.Protocol)[0] == RIB_PROTOCOL_ISIS {
+		rr.SetOriginType(gosnappi.IsisV6RouteRangeOriginType.INTERNAL)
+	} else {
+		rr.SetOriginType(gosnappi.IsisV6RouteRangeOriginType.EXTERNAL)
+	}
+	if route.Protocol == "i ia" {
+		rr.SetRedistributionType(gosnappi.IsisV6RouteRangeRedistributionType.DOWN)
+	}
+
+	return rr
+}
+
+// ParseRoutes parses RIB entries from the buffer without updating any target router
+func (imp *RibImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	imp.lines = strings.Split(string(*buffer), "\n")
+	routes := []Route{}
+	subnetMask := -1
+	inCodes := false
+	for index := 0; index < len(imp.lines); index++ {
+		line := strings.TrimRight(imp.lines[index], "\r")
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 {
+			inCodes = false
+			continue
+		}
+		if strings.HasPrefix(trimmed, RIB_CODES_PREFIX) {
+			inCodes = true
+			continue
+		}
+		if inCodes && line[0] == SPACE_CHAR {
+			// continuation of codes legend
+			continue
+		}
+		inCodes = false
+		if strings.HasPrefix(trimmed, RIB_GATEWAY_PREFIX) {
+			continue
+		}
+		if line[0] == SPACE_CHAR {
+			// "10.0.0.0/24 is subnetted, 2 subnets" sets mask of following entries
+			fields := strings.Fields(trimmed)
+			if strings.Contains(trimmed, RIB_SUBNETTED_SUFFIX) && !strings.Contains(trimmed, "variably") {
+				if _, mask, err := ParseNetworkAddress(fields[0]); err == nil && strings.Contains(fields[0], "/") {
+					subnetMask = mask
+				}
+			}
+			continue
+		}
+
+		route, err := imp.parseRibEntry(line, index, subnetMask)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		if route == nil {
+			continue
+		}
+		if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+			(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+			continue
+		}
+		if !isSelectedProtocol(ic.RibProtocols, route.Protocol, false) {
+			continue
+		}
+		routes = append(routes, *route)
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+	routes, err := processRoutes(&ic, routes, DEFAULT_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// parseRibEntry parses a RIB entry like
+// "O IA     10.0.34.0/24 [110/3] via 10.0.12.2, 00:10:11, GigabitEthernet0/1" or
+// "OE2 2001:DB8:200::/64 [110/20]" followed by "     via FE80::2, GigabitEthernet0/1"
+func (imp *RibImporter) parseRibEntry(line string, row int, subnetMask int) (*Route, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, nil
+	}
+	code := strings.TrimSuffix(fields[0], RIB_CANDIDATE_MARKER)
+	next := 1
+	if ribSubCodes[strings.TrimSuffix(fields[1], RIB_CANDIDATE_MARKER)] && len(fields) > 2 {
+		code = code + " " + strings.TrimSuffix(fields[1], RIB_CANDIDATE_MARKER)
+		next = 2
+	}
+	if v4Code, ok := ribV6Codes[code]; ok && strings.Contains(fields[next], ":") {
+		code = v4Code
+	}
+
+	network := strings.TrimSuffix(fields[next], ",")
+	ip, mask, err := ParseNetworkAddress(network)
+	if err != nil {
+		// not a route entry
+		return nil, nil
+	}
+	if !strings.Contains(network, "/") {
+		if subnetMask == -1 {
+			return nil, fmt.Errorf("missing mask for %q (line %d)", network, row+1)
+		}
+		mask = subnetMask
+	}
+	route := &Route{
+		Row:       row,
+		Network:   ip,
+		PrefixLen: mask,
+		Protocol:  code,
+		Best:      true,
+	}
+
+	rest := strings.Join(fields[next+1:], " ")
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return nil, fmt.Errorf("invalid distance / metric in %q (line %d)", rest, row+1)
+		}
+		values := strings.Split(rest[1:end], "/")
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid distance / metric in %q (line %d)", rest, row+1)
+		}
+		distance, err := strconv.ParseUint(values[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid distance %q (line %d)", values[0], row+1)
+		}
+		metric, err := strconv.ParseUint(values[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric %q (line %d)", values[1], row+1)
+		}
+		d, m := uint32(distance), uint32(metric)
+		route.Distance, route.Metric = &d, &m
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	if !strings.Contains(rest, RIB_VIA) && row+1 < len(imp.lines) {
+		// IPv6 next hop follows in next line
+		if nextLine := strings.TrimSpace(imp.lines[row+1]); strings.HasPrefix(nextLine, RIB_VIA) {
+			rest = nextLine
+		}
+	}
+	if offset := strings.Index(rest, RIB_VIA+" "); offset != -1 {
+		nextHop := strings.TrimSuffix(strings.Fields(rest[offset+len(RIB_VIA):])[0], ",")
+		if net.ParseIP(nextHop) != nil {
+			route.NextHop = nextHop
+		}
+	}
+
+	return route, nil
+}
+
+// isSelectedProtocol checks if protocol code matches a listed code or its base
+// code (e.g. "O" matches "O IA"), all codes match an empty list unless explicit
+func isSelectedProtocol(protocols []string, code string, explicit bool) bool {
+	if len(protocols) == 0 {
+		return !explicit
+	}
+	base := strings.Fields(code)[0]
+	for _, p := range protocols {
+		if p == code || p == base {
+			return true
+		}
+	}
+
+	return false
+}
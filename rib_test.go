@@ -0,0 +1,187 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type RibEntry struct {
+	Protocol  string
+	Network   string
+	PrefixLen int
+	NextHop   string
+	Metric    uint32
+}
+
+func TestImportRibRoutes(t *testing.T) {
+	filename := "resource/cisco_rib_v4.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCiscoRib)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "ribImp",
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	validateRibRoutes(t, routes, []RibEntry{
+		{Protocol: "S", Network: "0.0.0.0", PrefixLen: 0, NextHop: "10.0.12.2", Metric: 0},
+		{Protocol: "C", Network: "10.0.12.0", PrefixLen: 24},
+		{Protocol: "L", Network: "10.0.12.1", PrefixLen: 32},
+		{Protocol: "O", Network: "10.0.23.0", PrefixLen: 24, NextHop: "10.0.12.2", Metric: 2},
+		{Protocol: "O IA", Network: "10.0.34.0", PrefixLen: 24, NextHop: "10.0.12.2", Metric: 3},
+		{Protocol: "O E2", Network: "172.16.1.0", PrefixLen: 24, NextHop: "10.0.12.2", Metric: 20},
+		{Protocol: "O E1", Network: "172.16.2.0", PrefixLen: 24, NextHop: "10.0.12.2", Metric: 22},
+		{Protocol: "i L1", Network: "192.168.1.0", PrefixLen: 24, NextHop: "10.0.12.3", Metric: 20},
+		{Protocol: "i L2", Network: "192.168.2.0", PrefixLen: 24, NextHop: "10.0.12.3", Metric: 30},
+		{Protocol: "i ia", Network: "192.168.3.0", PrefixLen: 24, NextHop: "10.0.12.3", Metric: 40},
+	})
+
+	router := gosnappi.NewDeviceIsisRouter().SetName("isisA").SetSystemId("640000000001")
+	ic.TargetIsisRouters = []gosnappi.DeviceIsisRouter{router}
+	ic.RibProtocols = []string{"i", "O", "S"}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	// OSPF routes are not imported, static route is redistributed
+	expRouteCount := 4
+	if len(*names) != expRouteCount || len(router.V4Routes().Items()) != expRouteCount {
+		t.Errorf("Expected Route Count: %d, Imported Routes Count: %d", expRouteCount, len(*names))
+		return
+	}
+	items := router.V4Routes().Items()
+	if items[0].OriginType() != gosnappi.IsisV4RouteRangeOriginType.EXTERNAL ||
+		items[1].OriginType() != gosnappi.IsisV4RouteRangeOriginType.INTERNAL ||
+		items[2].LinkMetric() != 30 ||
+		items[3].RedistributionType() != gosnappi.IsisV4RouteRangeRedistributionType.DOWN {
+		t.Errorf("IS-IS route range mismatch. Found: %v", items)
+	}
+}
+
+func TestImportRibRoutesV6(t *testing.T) {
+	filename := "resource/cisco_rib_v6.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCiscoRib)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	router := gosnappi.NewDeviceIsisRouter().SetName("isisA").SetSystemId("640000000001")
+	ic := routeimporter.ImportConfig{
+		NamePrefix:        "ribImp",
+		RRType:            routeimporter.RouteTypeIpv6,
+		RibProtocols:      []string{"i L2", "O E2"},
+		TargetIsisRouters: []gosnappi.DeviceIsisRouter{router},
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	validateRibRoutes(t, routes, []RibEntry{
+		{Protocol: "i L2", Network: "2001:db8:101::", PrefixLen: 64, NextHop: "FE80::2", Metric: 30},
+		{Protocol: "O E2", Network: "2001:db8:200::", PrefixLen: 64, NextHop: "FE80::3", Metric: 20},
+	})
+
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 1 || len(router.V6Routes().Items()) != 1 {
+		t.Errorf("Expected Route Count: 1, Imported Routes Count: %d", len(*names))
+	}
+}
+
+func validateRibRoutes(t *testing.T, routes *[]routeimporter.Route, expRoutes []RibEntry) {
+	if len(*routes) != len(expRoutes) {
+		t.Errorf("Expected Route Count: %d, Parsed Routes Count: %d", len(expRoutes), len(*routes))
+		return
+	}
+	for i, exp := range expRoutes {
+		route := (*routes)[i]
+		found := RibEntry{
+			Protocol:  route.Protocol,
+			Network:   route.Network.String(),
+			PrefixLen: route.PrefixLen,
+			NextHop:   route.NextHop,
+		}
+		if route.Metric != nil {
+			found.Metric = *route.Metric
+		}
+		if found != exp {
+			t.Errorf("Route mismatch at %d. Expected: %v, Parsed: %v", i, exp, found)
+		}
+	}
+}
+
+func TestImportRibRoutesMerge(t *testing.T) {
+	filename := "resource/cisco_rib_v4.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCiscoRib)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	router := gosnappi.NewDeviceIsisRouter().SetName("isisA").SetSystemId("640000000001")
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:        "ribImp",
+		RibProtocols:      []string{"i", "S"},
+		TargetIsisRouters: []gosnappi.DeviceIsisRouter{router},
+		Report:            &report,
+	}
+	for _, exp := range []struct {
+		Mode                                       routeimporter.MergeMode
+		Ranges, Added, Renamed, Unchanged, Removed int
+	}{
+		{routeimporter.MergeModeAppend, 4, 4, 0, 0, 0},
+		{routeimporter.MergeModeAppend, 8, 4, 4, 0, 0},
+		{routeimporter.MergeModeSkip, 8, 0, 0, 4, 0},
+		{routeimporter.MergeModeReplace, 4, 4, 0, 0, 8},
+	} {
+		ic.MergeMode = exp.Mode
+		if _, err := is.ImportRoutes(ic, &fb); err != nil {
+			t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+			return
+		}
+		if len(router.V4Routes().Items()) != exp.Ranges || report.Added != exp.Added || report.Renamed != exp.Renamed ||
+			report.Unchanged != exp.Unchanged || report.Removed != exp.Removed {
+			t.Errorf("Merge mode %v: expected %+v, found %d route ranges, report %+v", exp.Mode, exp,
+				len(router.V4Routes().Items()), report)
+		}
+	}
+
+	ic.RouteGroups = routeimporter.RouteGroupAll
+	if _, err := is.ImportRoutes(ic, &fb); err == nil {
+		t.Errorf("Expected error for route groups of IS-IS router")
+	}
+}
@@ -18,13 +18,150 @@ func newCiscoImporter() (ImportService, error) {
 	return is, nil
 }
 
+func newEvpnImporter() (ImportService, error) {
+	gid += 1
+	is := &EvpnImporter{
+		id: gid,
+	}
+	log.Info().Msgf("EvpnImporter: %v created", is)
+
+	return is, nil
+}
+
+func newRibImporter() (ImportService, error) {
+	gid += 1
+	is := &RibImporter{
+		id: gid,
+	}
+	log.Info().Msgf("RibImporter: %v created", is)
+
+	return is, nil
+}
+
+func newMrtUpdateImporter() (ImportService, error) {
+	gid += 1
+	is := &MrtUpdateImporter{
+		id: gid,
+	}
+	log.Info().Msgf("MrtUpdateImporter: %v created", is)
+
+	return is, nil
+}
+
+func newCsvImporter() (ImportService, error) {
+	gid += 1
+	is := &CsvImporter{
+		id: gid,
+	}
+	log.Info().Msgf("CsvImporter: %v created", is)
+
+	return is, nil
+}
+
+func newRouteListImporter() (ImportService, error) {
+	gid += 1
+	is := &RouteListImporter{
+		id: gid,
+	}
+	log.Info().Msgf("RouteListImporter: %v created", is)
+
+	return is, nil
+}
+
+func newBirdImporter() (ImportService, error) {
+	gid += 1
+	is := &BirdImporter{
+		id: gid,
+	}
+	log.Info().Msgf("BirdImporter: %v created", is)
+
+	return is, nil
+}
+
+func newGobgpImporter() (ImportService, error) {
+	gid += 1
+	is := &GobgpImporter{
+		id: gid,
+	}
+	log.Info().Msgf("GobgpImporter: %v created", is)
+
+	return is, nil
+}
+
+func newExabgpImporter() (ImportService, error) {
+	gid += 1
+	is := &ExabgpImporter{
+		id: gid,
+	}
+	log.Info().Msgf("ExabgpImporter: %v created", is)
+
+	return is, nil
+}
+
 func GetImporterService(format ImportFileType) (ImportService, error) {
 	switch format {
 	case ImportFileTypeCisco:
 		return newCiscoImporter()
 	case ImportFileTypeJuniper:
 		return nil, fmt.Errorf("support for Juniper is not yet implemented")
+	case ImportFileTypeEvpn:
+		return newEvpnImporter()
+	case ImportFileTypeCiscoRib:
+		return newRibImporter()
+	case ImportFileTypeMrtUpdates:
+		return newMrtUpdateImporter()
+	case ImportFileTypeCsv:
+		return newCsvImporter()
+	case ImportFileTypeRouteList:
+		return newRouteListImporter()
+	case ImportFileTypeBird:
+		return newBirdImporter()
+	case ImportFileTypeGobgp:
+		return newGobgpImporter()
+	case ImportFileTypeExabgp:
+		return newExabgpImporter()
 	default:
 		return nil, fmt.Errorf("unknown importer type format : %v", format)
 	}
 }
+
+// ParseRoutes parses routes of the buffer with the import service, if it implements RouteParser
+func ParseRoutes(is ImportService, ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	parser, ok := is.(RouteParser)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse routes - %v does not implement RouteParser", is)
+	}
+
+	return parser.ParseRoutes(ic, buffer)
+}
+
+func newCiscoExporter() (ExportService, error) {
+	gid += 1
+	es := &CiscoExporter{
+		id: gid,
+	}
+	log.Info().Msgf("CiscoExporter: %v created", es)
+
+	return es, nil
+}
+
+func newMrtExporter() (ExportService, error) {
+	gid += 1
+	es := &MrtExporter{
+		id: gid,
+	}
+	log.Info().Msgf("MrtExporter: %v created", es)
+
+	return es, nil
+}
+
+func GetExporterService(format ExportFileType) (ExportService, error) {
+	switch format {
+	case ExportFileTypeCisco:
+		return newCiscoExporter()
+	case ExportFileTypeMrt:
+		return newMrtExporter()
+	default:
+		return nil, fmt.Errorf("unknown exporter type format : %v", format)
+	}
+}
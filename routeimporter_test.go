@@ -194,3 +194,102 @@ func TestImportRoutes1KV4(t *testing.T) {
 		t.Errorf("Could not successfully imported all routes. Number of missing routes found: %d", len(rEntryList))
 	}
 }
+
+func TestImportRoutesVpnV4(t *testing.T) {
+	filename := "resource/cisco_vpnv4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+	}
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "txImp",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	expRoutes := []struct {
+		Network string
+		Rd      string
+		Vrf     string
+	}{
+		{Network: "10.1.1.0", Rd: "65000:1", Vrf: "RED"},
+		{Network: "10.1.2.0", Rd: "65000:1", Vrf: "RED"},
+		{Network: "10.1.2.0", Rd: "65000:1", Vrf: "RED"},
+		{Network: "10.2.1.0", Rd: "65000:2", Vrf: "BLUE"},
+		{Network: "10.2.2.0", Rd: "65000:2", Vrf: "BLUE"},
+		{Network: "10.3.1.0", Rd: "10.0.0.4:3", Vrf: ""},
+	}
+	if len(*routes) != len(expRoutes) {
+		t.Errorf("Expected Route Count: %d, Parsed Routes Count: %d", len(expRoutes), len(*routes))
+		return
+	}
+	for i, exp := range expRoutes {
+		route := (*routes)[i]
+		if route.Network.String() != exp.Network || route.Rd != exp.Rd || route.Vrf != exp.Vrf {
+			t.Errorf("Route mismatch at %d. Expected: %v, Parsed: %s %s %s",
+				i, exp, route.Network.String(), route.Rd, route.Vrf)
+		}
+	}
+
+	peer := gosnappi.NewBgpV4Peer()
+	ic.BestRoutes = true
+	ic.Vrfs = []string{"BLUE", "10.0.0.4:3"}
+	ic.VrfRouteTargets = map[string][]string{"BLUE": {"65000:100", "65000:200"}}
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peer}
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	expRouteCount := 3
+	if len(*names) != expRouteCount {
+		t.Errorf("Expected Route Count: %d, Imported Routes Count: %d", expRouteCount, len(*names))
+		return
+	}
+	expExtCommunities := []string{"fde800000064", "fde8000000c8"}
+	extCommunities := peer.V4Routes().Items()[0].ExtCommunities().Items()
+	if len(extCommunities) != len(expExtCommunities) {
+		t.Errorf("Expected Route Target Count: %d, Found: %d", len(expExtCommunities), len(extCommunities))
+		return
+	}
+	for i, ec := range extCommunities {
+		if ec.Subtype() != gosnappi.BgpExtCommunitySubtype.ROUTE_TARGET || ec.Value() != expExtCommunities[i] {
+			t.Errorf("Route target mismatch. Expected: %s, Found: %s", expExtCommunities[i], ec.Value())
+		}
+	}
+	ec := peer.V4Routes().Items()[2].ExtCommunities().Items()[0]
+	if ec.Type() != gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS || ec.Value() != "0a0000040003" {
+		t.Errorf("Route target mismatch. Expected: 0a0000040003, Found: %s", ec.Value())
+	}
+}
+
+func TestCiscoDeprecatedProcessv4(t *testing.T) {
+	imp := &routeimporter.CiscoImporter{}
+	rr := gosnappi.NewBgpV4RouteRange()
+	if err := imp.Processv4Nexthop(rr, "192.0.2.1", 0); err != nil || rr.NextHopIpv4Address() != "192.0.2.1" {
+		t.Errorf("Unexpected next hop %s, error: %v", rr.NextHopIpv4Address(), err)
+	}
+	if err := imp.Processv4LocalPrf(rr, "200", 0); err != nil || rr.Advanced().LocalPreference() != 200 {
+		t.Errorf("Unexpected local pref %d, error: %v", rr.Advanced().LocalPreference(), err)
+	}
+	if err := imp.Processv4Metric(rr, "10", 0); err != nil || rr.Advanced().MultiExitDiscriminator() != 10 {
+		t.Errorf("Unexpected MED %d, error: %v", rr.Advanced().MultiExitDiscriminator(), err)
+	}
+	if err := imp.Processv4AsPath(rr, "6939 {7545,56203} i", 0); err != nil || len(rr.AsPath().Segments().Items()) != 2 {
+		t.Errorf("Unexpected AS path %v, error: %v", rr.AsPath().Segments().Items(), err)
+	}
+	if err := imp.Processv4Metric(rr, "x", 0); err == nil {
+		t.Errorf("Expected error for invalid MED")
+	}
+}
@@ -0,0 +1,204 @@
+package routeimporter
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ROUTE_LIST_ROUTES   = "routes"
+	ROUTE_LIST_DEFAULTS = "defaults"
+)
+
+// RouteListImporter imports routes from YAML / JSON route lists
+type RouteListImporter struct {
+	id uint64
+
+	validRoutes int
+	startTask   time.Time
+}
+
+// String returns the id of the client.
+func (imp *RouteListImporter) String() string {
+	return fmt.Sprintf("Route List Importer, session id: %8d, validRoutes:%d",
+		imp.id, imp.validRoutes)
+}
+
+// Detect checks for a YAML routes / defaults key or a JSON object with routes
+func (imp *RouteListImporter) Detect(buffer *[]byte) bool {
+	for _, line := range detectLines(buffer) {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' || line == "---" {
+			continue
+		}
+		if line[0] == '{' {
+			return bytes.Contains(*buffer, []byte(fmt.Sprintf("%q", ROUTE_LIST_ROUTES)))
+		}
+		return strings.HasPrefix(line, ROUTE_LIST_ROUTES+":") || strings.HasPrefix(line, ROUTE_LIST_DEFAULTS+":")
+	}
+
+	return false
+}
+
+// ImportRoutes adds one route range per listed route to the target peer named by the route,
+// or else IPv4 routes to the target v4 peer and IPv6 routes to the target v6 peer.
+func (imp *RouteListImporter) ImportRoutes(ic ImportConfig, buffer *[]byte) (*[]string, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+	if len(ic.Targetv4Peers) == 0 && len(ic.Targetv6Peers) == 0 {
+		return nil, fmt.Errorf("cannot import, no target peers found")
+	}
+
+	routes, err := imp.ParseRoutes(ic, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.startTask = time.Now()
+	route_names := []string{}
+	merger, err := newPeerTargetMerger(&ic, *routes)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *routes {
+		route := &(*routes)[i]
+		name, err := merger.add(route)
+		if err != nil {
+			log.Info().Msgf(err.Error())
+			continue
+		}
+		route_names = append(route_names, name)
+		imp.validRoutes++
+	}
+	merger.done()
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Peer update")
+
+	return &route_names, nil
+}
+
+// ParseRoutes parses the route list, entries with count into one route of consecutive prefixes.
+// Routes are named by sequence, as entries have no row.
+func (imp *RouteListImporter) ParseRoutes(ic ImportConfig, buffer *[]byte) (*[]Route, error) {
+	if buffer == nil || len(*buffer) == 0 {
+		return nil, fmt.Errorf("cannot import - empty route buffer")
+	}
+
+	imp.startTask = time.Now()
+	list := RouteList{}
+	if err := yaml.Unmarshal(*buffer, &list); err != nil {
+		return nil, fmt.Errorf("cannot import - %v", err)
+	}
+
+	routes := []Route{}
+	for index, entry := range list.Routes {
+		route, err := entry.withDefaults(&list.Defaults).route(index)
+		if err != nil {
+			return nil, err
+		}
+		if (route.Network.To4() != nil && ic.RRType == RouteTypeIpv6) ||
+			(route.Network.To4() == nil && ic.RRType == RouteTypeIpv4) {
+			continue
+		}
+		routes = append(routes, *route)
+	}
+	log.Info().Int64("milisecs", time.Since(imp.startTask).Milliseconds()).Msg("Route parsing")
+	routes, err := processRoutes(&ic, routes, SEQ_NAME_TEMPLATE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routes, nil
+}
+
+// withDefaults returns the entry with unset attributes taken from defaults
+func (entry RouteListEntry) withDefaults(defaults *RouteListEntry) RouteListEntry {
+	if entry.NextHop == "" {
+		entry.NextHop = defaults.NextHop
+	}
+	if entry.AsPath == "" {
+		entry.AsPath = defaults.AsPath
+	}
+	if entry.Origin == "" {
+		entry.Origin = defaults.Origin
+	}
+	if entry.Med == nil {
+		entry.Med = defaults.Med
+	}
+	if entry.LocalPref == nil {
+		entry.LocalPref = defaults.LocalPref
+	}
+	if entry.Communities == nil {
+		entry.Communities = defaults.Communities
+	}
+	if entry.Peer == "" {
+		entry.Peer = defaults.Peer
+	}
+
+	return entry
+}
+
+// route returns the route of a route list entry, a route range of count prefixes
+func (entry RouteListEntry) route(index int) (*Route, error) {
+	ip, length, err := parsePrefix(entry.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%v (route %d)", err, index+1)
+	}
+	route := &Route{Row: index, PrefixLen: length, NextHop: entry.NextHop, PathId: entry.PathId, Peer: entry.Peer,
+		Metric: copyValue(entry.Med), LocalPref: copyValue(entry.LocalPref), Communities: append([]string(nil), entry.Communities...)}
+	if route.AsPath, err = parseAsSegments(entry.AsPath, index); err != nil {
+		return nil, fmt.Errorf("invalid AS path %q (route %d)", entry.AsPath, index+1)
+	}
+	if entry.Origin != "" {
+		if route.Origin, err = parseOriginName(entry.Origin); err != nil {
+			return nil, fmt.Errorf("%v (route %d)", err, index+1)
+		}
+	}
+	if entry.NextHop != "" && net.ParseIP(entry.NextHop) == nil {
+		return nil, fmt.Errorf("invalid next hop %q (route %d)", entry.NextHop, index+1)
+	}
+	for _, c := range entry.Communities {
+		if _, err := communityValue(c); err != nil {
+			return nil, fmt.Errorf("%v (route %d)", err, index+1)
+		}
+	}
+
+	count := entry.Count
+	if count == 0 {
+		count = 1
+	}
+	if count < 0 || int64(count) > math.MaxUint32 {
+		return nil, fmt.Errorf("invalid count %d (route %d)", entry.Count, index+1)
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	bits := len(ip) * 8
+	last := new(big.Int).Lsh(big.NewInt(int64(count-1)), uint(bits-length))
+	if last.Add(last, new(big.Int).SetBytes(ip)).BitLen() > bits {
+		return nil, fmt.Errorf("count %d of %s exceeds address space (route %d)", count, entry.Prefix, index+1)
+	}
+	route.Network = ip.To16()
+	if count > 1 {
+		route.Count = count
+	}
+
+	return route, nil
+}
+
+// copyValue returns a copy of an optional attribute value
+func copyValue(value *uint32) *uint32 {
+	if value == nil {
+		return nil
+	}
+	v := *value
+	return &v
+}
@@ -0,0 +1,202 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestParseRoutesRouteList(t *testing.T) {
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeRouteList)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	parsed := [][]routeimporter.Route{}
+	for _, filename := range []string{"resource/routes.yaml", "resource/routes.json"} {
+		fb, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+			return
+		}
+		routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "rl"}, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes of %s. error: %v", filename, err))
+			return
+		}
+		if _, name, err := routeimporter.DetectImporterService(&fb); err != nil || name != "route-list" {
+			t.Errorf("%s: expected route-list format detected, found %q, error: %v", filename, name, err)
+		}
+		parsed = append(parsed, *routes)
+	}
+	if !reflect.DeepEqual(parsed[0], parsed[1]) {
+		t.Errorf("Expected same routes from YAML and JSON route lists")
+	}
+
+	routes := parsed[0]
+	if len(routes) != 3 {
+		t.Errorf("Expected 3 routes, found %d", len(routes))
+		return
+	}
+	first := routes[0]
+	if first.Name != "rl-1" || first.Network.String() != "10.0.0.0" || first.PrefixLen != 24 || first.Count != 4 ||
+		first.NextHop != "192.0.2.1" || *first.LocalPref != 100 || first.Origin != gosnappi.BgpRouteAdvancedOrigin.IGP ||
+		!reflect.DeepEqual(first.Communities, []string{"65000:100", "no-export"}) {
+		t.Errorf("Expected route with count and default attributes %+v", first)
+	}
+	second := routes[1]
+	if second.Name != "rl-2" || second.Count != 0 || *second.Metric != 20 || *second.LocalPref != 200 || second.PathId != 2 ||
+		second.Peer != "peerB" || second.AsPath[1].Type != gosnappi.BgpAsPathSegmentType.AS_SET {
+		t.Errorf("Unexpected route %+v", second)
+	}
+	third := routes[2]
+	if third.Network.String() != "2001:db8:1::" || third.NextHop != "2001:db8::1" ||
+		third.Origin != gosnappi.BgpRouteAdvancedOrigin.INCOMPLETE || third.AsPath[0].AsNumbers[0] != 4259840100 {
+		t.Errorf("Unexpected route %+v", third)
+	}
+
+	for _, list := range []string{
+		"routes:\n  - prefix: 10.0.0.0\n",
+		"routes:\n  - prefix: 255.255.255.0/24\n    count: 2\n",
+		"routes:\n  - prefix: 2001:db8::/64\n    count: 4294967296\n",
+		"routes:\n  - prefix: 10.0.0.0/24\n    communities: [65536:1]\n",
+		"routes:\n  - prefix: 10.0.0.0/24\n    origin: bgp\n",
+	} {
+		fb := []byte(list)
+		if _, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "rl"}, &fb); err == nil {
+			t.Errorf("Expected error for route list %q", list)
+		}
+	}
+}
+
+func TestImportRoutesRouteListPeers(t *testing.T) {
+	filename := "resource/routes.yaml"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	bgp := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1")
+	intf := bgp.Ipv4Interfaces().Add().SetIpv4Name("intA")
+	peerA := intf.Peers().Add().SetName("peerA")
+	peerA.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	peerB := intf.Peers().Add().SetName("peerB")
+	peerB.SetPeerAddress("1.1.1.2").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	peerV6 := bgp.Ipv6Interfaces().Add().SetIpv6Name("intB").Peers().Add().SetName("peerC")
+	peerV6.SetPeerAddress("::1").SetAsType(gosnappi.BgpV6PeerAsType.IBGP).SetAsNumber(65001)
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeRouteList)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "rl",
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peerA, peerB},
+		Targetv6Peers: []gosnappi.BgpV6Peer{peerV6},
+		Report:        &report,
+	}
+	// routes without target peer go to the target peers that no route names
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 3 || len(peerA.V4Routes().Items()) != 1 || len(peerB.V4Routes().Items()) != 1 ||
+		len(peerV6.V6Routes().Items()) != 1 || peerB.V4Routes().Items()[0].Name() != "rl-2" {
+		t.Errorf("Expected 1 route range on each of peerA, peerB and peerC, found %d, %d, %d", len(peerA.V4Routes().Items()),
+			len(peerB.V4Routes().Items()), len(peerV6.V6Routes().Items()))
+		return
+	}
+	// route with count is one route range of consecutive prefixes
+	addr := peerA.V4Routes().Items()[0].Addresses().Items()[0]
+	if addr.Address() != "10.0.0.0" || addr.Prefix() != 24 || addr.Count() != 4 || addr.Step() != 1 {
+		t.Errorf("Unexpected route range addresses %s/%d count %d step %d", addr.Address(), addr.Prefix(), addr.Count(), addr.Step())
+	}
+	if report.Added != 3 {
+		t.Errorf("Expected 3 added routes in report, found %d", report.Added)
+	}
+
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peerA}
+	if _, err := is.ImportRoutes(ic, &fb); err == nil {
+		t.Errorf("Expected error for target peer not found")
+	}
+
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peerA, peerB}
+	ic.MergeMode = routeimporter.MergeModeReplace
+	fb = []byte("defaults:\n  next_hop: 192.0.2.1\n  peer: peerA\nroutes:\n" +
+		"  - prefix: 10.0.0.0/24\n    count: 4\n  - prefix: 10.1.0.0/16\n    peer: peerB\n")
+	names, err = is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 2 || len(peerA.V4Routes().Items()) != 1 || len(peerB.V4Routes().Items()) != 1 ||
+		len(peerV6.V6Routes().Items()) != 1 {
+		t.Errorf("Expected 1 route range on peerA and 1 on peerB, found %d, %d", len(peerA.V4Routes().Items()),
+			len(peerB.V4Routes().Items()))
+	}
+	if report.Added != 2 || report.Removed != 2 {
+		t.Errorf("Expected 2 added and 2 removed routes in report, found %d, %d", report.Added, report.Removed)
+	}
+}
+
+func TestParseRoutesRouteListCountBogons(t *testing.T) {
+	// 223.255.254.0/24 ... 224.0.1.0/24, the last two in multicast space
+	fb := []byte("defaults:\n  next_hop: 192.0.2.1\n  as_path: \"13335\"\nroutes:\n" +
+		"  - prefix: 223.255.254.0/24\n    count: 4\n")
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeRouteList)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "rl"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	stats, err := routeimporter.Analyze(routes, 0)
+	if err != nil || stats.Total != 4 {
+		t.Errorf("Expected 4 prefixes analyzed of route range, found %+v, error: %v", stats, err)
+	}
+	exp, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	out, err := exp.ExportRoutes(routes)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+	if !strings.Contains(string(*out), "224.0.1.0/24") {
+		t.Errorf("Expected last prefix of route range exported, found %s", *out)
+	}
+
+	bogons := routeimporter.DefaultBogonFilter()
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{NamePrefix: "rl", Bogons: &bogons, Report: &report}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	networks := []string{}
+	for _, route := range *routes {
+		networks = append(networks, fmt.Sprintf("%s/%d", route.Network, route.PrefixLen))
+	}
+	if !reflect.DeepEqual(networks, []string{"223.255.254.0/24", "223.255.255.0/24"}) || len(report.Filtered) != 2 {
+		t.Errorf("Expected multicast prefixes of route range filtered, found %v, %d filtered", networks, len(report.Filtered))
+	}
+}
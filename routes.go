@@ -0,0 +1,669 @@
+package routeimporter
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/rs/zerolog/log"
+)
+
+// processRoutes applies the validation and naming stages of the import config to
+// parsed routes, returning the routes selected for import. Route ranges with count are
+// expanded into their prefixes if weighted, scaled, filtered or sampled.
+func processRoutes(ic *ImportConfig, routes []Route, fallback string) ([]Route, error) {
+	if ic.WeightLocalPref || (ic.Scale != nil && ic.Scale.Copies != 0) || ic.Bogons != nil ||
+		len(ic.Roas) > 0 || ic.Sample != SampleNone {
+		routes = expandRoutes(routes)
+	}
+	if ic.WeightLocalPref {
+		weightLocalPref(routes)
+	}
+	routes, scaleSkipped, err := scaleRoutes(ic, routes)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newRouteFilter(ic)
+	if err != nil {
+		return nil, err
+	}
+	selected := []Route{}
+	filtered := []FilteredRoute{}
+	for _, route := range routes {
+		if reason := filter.check(&route); reason != "" {
+			filtered = append(filtered, FilteredRoute{Route: route, Reason: reason})
+			continue
+		}
+		selected = append(selected, route)
+	}
+	asTrans := 0
+	for i := range selected {
+		if hasAsTrans(selected[i].AsPath) {
+			asTrans++
+		}
+	}
+	if asTrans > 0 {
+		log.Info().Msgf("%d routes with AS_TRANS (%d) in AS path, sent by 2 byte AS speakers", asTrans, AS_TRANS)
+	}
+	if ic.Report != nil {
+		ic.Report.Filtered = filtered
+		ic.Report.AsTrans = asTrans
+		ic.Report.ScaleSkipped = scaleSkipped
+	}
+	routes, err = sampleRoutes(ic, selected)
+	if err != nil {
+		return nil, err
+	}
+	if err := nameRoutes(ic, routes, fallback); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// expandRoutes returns the routes with route ranges of count expanded into one route
+// per prefix, the routes as is if there are none
+func expandRoutes(routes []Route) []Route {
+	ranges := 0
+	for i := range routes {
+		if routes[i].Count > 1 {
+			ranges++
+		}
+	}
+	if ranges == 0 {
+		return routes
+	}
+
+	expanded := []Route{}
+	for _, route := range routes {
+		if route.Count <= 1 {
+			expanded = append(expanded, route)
+			continue
+		}
+		ip := route.Network
+		if v4 := ip.To4(); v4 != nil {
+			ip = v4
+		}
+		step := new(big.Int).Lsh(big.NewInt(1), uint(len(ip)*8-route.PrefixLen))
+		for i := 0; i < route.Count; i++ {
+			r := route
+			r.Network, r.Count = addAddress(ip, step, i).To16(), 0
+			expanded = append(expanded, r)
+		}
+	}
+
+	return expanded
+}
+
+// newV4RouteRange creates a bgp v4 route range from the parsed route
+func newV4RouteRange(route *Route, ic *ImportConfig, peer gosnappi.BgpV4Peer) (gosnappi.BgpV4RouteRange, error) {
+	rr := gosnappi.NewBgpV4RouteRange()
+	rr.SetName(route.Name)
+	addr := rr.Addresses().Add().SetAddress(route.Network.String()).SetPrefix(uint32(route.PrefixLen))
+	if route.Count > 1 {
+		addr.SetCount(uint32(route.Count)).SetStep(1)
+	}
+
+	// process nexthop, eBGP peers of split imports advertise their own address
+	if !ic.RetainNexthop || (ic.SplitInternal && peer.AsType() == gosnappi.BgpV4PeerAsType.EBGP) {
+		rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.LOCAL_IP)
+	} else {
+		ip := net.ParseIP(route.NextHop)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address: %q for Nexthop processing (line %d)", route.NextHop, route.Row+1)
+		}
+		rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		if ip.To4() != nil {
+			rr.SetNextHopIpv4Address(ip.String())
+		} else {
+			rr.SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6)
+			rr.SetNextHopIpv6Address(ip.String())
+		}
+	}
+
+	if route.PathId != 0 {
+		rr.AddPath().SetPathId(route.PathId)
+	}
+	route = routeForAsWidth(route, ic)
+	if err := setRouteRangeAttributes(route, rr.Advanced(), rr.AsPath(),
+		peer.AsType() == gosnappi.BgpV4PeerAsType.EBGP, rr.Communities().Add, rr.ExtCommunities().Add); err != nil {
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+// newV6RouteRange creates a bgp v6 route range from the parsed route
+func newV6RouteRange(route *Route, ic *ImportConfig, peer gosnappi.BgpV6Peer) (gosnappi.BgpV6RouteRange, error) {
+	rr := gosnappi.NewBgpV6RouteRange()
+	rr.SetName(route.Name)
+	addr := rr.Addresses().Add().SetAddress(route.Network.String()).SetPrefix(uint32(route.PrefixLen))
+	if route.Count > 1 {
+		addr.SetCount(uint32(route.Count)).SetStep(1)
+	}
+
+	// process nexthop, eBGP peers of split imports advertise their own address
+	if !ic.RetainNexthop || (ic.SplitInternal && peer.AsType() == gosnappi.BgpV6PeerAsType.EBGP) {
+		rr.SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.LOCAL_IP)
+	} else {
+		ip := net.ParseIP(route.NextHop)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address: %q for Nexthop processing (line %d)", route.NextHop, route.Row+1)
+		}
+		rr.SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+		if ip.To4() != nil {
+			rr.SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV4)
+			rr.SetNextHopIpv4Address(ip.String())
+		} else {
+			rr.SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6)
+			rr.SetNextHopIpv6Address(ip.String())
+		}
+	}
+
+	if route.PathId != 0 {
+		rr.AddPath().SetPathId(route.PathId)
+	}
+	route = routeForAsWidth(route, ic)
+	if err := setRouteRangeAttributes(route, rr.Advanced(), rr.AsPath(),
+		peer.AsType() == gosnappi.BgpV6PeerAsType.EBGP, rr.Communities().Add, rr.ExtCommunities().Add); err != nil {
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+// targetPeers returns the target v4 / v6 peer of the import config, one of them is required
+func targetPeers(ic *ImportConfig) (gosnappi.BgpV4Peer, gosnappi.BgpV6Peer, error) {
+	if len(ic.Targetv4Peers) > 1 || len(ic.Targetv6Peers) > 1 {
+		// To be handled in future
+		return nil, nil, fmt.Errorf("multiple target peers currently not supported")
+	}
+	if len(ic.Targetv4Peers) == 0 && len(ic.Targetv6Peers) == 0 {
+		return nil, nil, fmt.Errorf("cannot import, no target peers found")
+	}
+	var peerV4 gosnappi.BgpV4Peer
+	var peerV6 gosnappi.BgpV6Peer
+	if len(ic.Targetv4Peers) > 0 {
+		peerV4 = ic.Targetv4Peers[0]
+	}
+	if len(ic.Targetv6Peers) > 0 {
+		peerV6 = ic.Targetv6Peers[0]
+	}
+
+	return peerV4, peerV6, nil
+}
+
+// splitTargetPeers returns the iBGP and eBGP target v4 / v6 peers of the import config,
+// at most one of each per address family
+func splitTargetPeers(ic *ImportConfig) (ibgpV4 gosnappi.BgpV4Peer, ibgpV6 gosnappi.BgpV6Peer,
+	ebgpV4 gosnappi.BgpV4Peer, ebgpV6 gosnappi.BgpV6Peer, err error) {
+	for _, peer := range ic.Targetv4Peers {
+		if peer.AsType() == gosnappi.BgpV4PeerAsType.EBGP {
+			if ebgpV4 != nil {
+				return nil, nil, nil, nil, fmt.Errorf("multiple eBGP target v4 peers currently not supported")
+			}
+			ebgpV4 = peer
+		} else {
+			if ibgpV4 != nil {
+				return nil, nil, nil, nil, fmt.Errorf("multiple iBGP target v4 peers currently not supported")
+			}
+			ibgpV4 = peer
+		}
+	}
+	for _, peer := range ic.Targetv6Peers {
+		if peer.AsType() == gosnappi.BgpV6PeerAsType.EBGP {
+			if ebgpV6 != nil {
+				return nil, nil, nil, nil, fmt.Errorf("multiple eBGP target v6 peers currently not supported")
+			}
+			ebgpV6 = peer
+		} else {
+			if ibgpV6 != nil {
+				return nil, nil, nil, nil, fmt.Errorf("multiple iBGP target v6 peers currently not supported")
+			}
+			ibgpV6 = peer
+		}
+	}
+	if ibgpV4 == nil && ibgpV6 == nil && ebgpV4 == nil && ebgpV6 == nil {
+		return nil, nil, nil, nil, fmt.Errorf("cannot import, no target peers found")
+	}
+
+	return ibgpV4, ibgpV6, ebgpV4, ebgpV6, nil
+}
+
+// routeForAsWidth returns the route with 4 byte AS numbers of the AS path replaced by
+// AS_TRANS when the target peers use 2 byte AS numbers
+func routeForAsWidth(route *Route, ic *ImportConfig) *Route {
+	if ic.AsNumberWidth != AsNumberWidthTwo {
+		return route
+	}
+	twoByte := *route
+	twoByte.AsPath = twoByteAsPath(route.AsPath)
+	return &twoByte
+}
+
+// setRouteRangeAttributes sets path attributes of the parsed route on a v4 / v6 route range
+func setRouteRangeAttributes(route *Route, advanced gosnappi.BgpRouteAdvanced, asPath gosnappi.BgpAsPath,
+	ebgp bool, addCommunity func() gosnappi.BgpCommunity, addExtCommunity func() gosnappi.BgpExtCommunity) error {
+	if route.LocalPref != nil {
+		advanced.SetIncludeLocalPreference(true)
+		advanced.SetLocalPreference(*route.LocalPref)
+	}
+	if route.Metric != nil {
+		advanced.SetIncludeMultiExitDiscriminator(true)
+		advanced.SetMultiExitDiscriminator(*route.Metric)
+	}
+	if route.Origin != "" {
+		advanced.SetIncludeOrigin(true)
+		advanced.SetOrigin(route.Origin)
+	}
+
+	if len(route.AsPath) > 0 {
+		if ebgp {
+			asPath.SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ)
+		}
+		for _, seg := range route.AsPath {
+			asPath.Segments().Add().SetType(seg.Type).SetAsNumbers(seg.AsNumbers)
+		}
+	}
+
+	for _, c := range route.Communities {
+		value, err := communityValue(c)
+		if err != nil {
+			return fmt.Errorf("%v (line %d)", err, route.Row+1)
+		}
+		community := addCommunity()
+		if cType, ok := wellKnownCommunityTypes[value]; ok {
+			community.SetType(cType)
+		} else {
+			community.SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER).
+				SetAsNumber(value >> 16).
+				SetAsCustom(value & 0xFFFF)
+		}
+	}
+
+	for _, rt := range route.RouteTargets {
+		rtType, value, err := routeTargetValue(rt)
+		if err != nil {
+			return fmt.Errorf("%v (line %d)", err, route.Row+1)
+		}
+		addExtCommunity().
+			SetType(rtType).
+			SetSubtype(gosnappi.BgpExtCommunitySubtype.ROUTE_TARGET).
+			SetValue(value)
+	}
+
+	return nil
+}
+
+// isSelectedVrf checks if routes of the vrf / route distinguisher are to be imported
+func isSelectedVrf(ic *ImportConfig, rd string, vrf string) bool {
+	if len(ic.Vrfs) == 0 {
+		return true
+	}
+	for _, v := range ic.Vrfs {
+		if (vrf != "" && v == vrf) || (rd != "" && v == rd) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// vrfRouteTargets returns the route targets configured for the vrf,
+// route distinguisher is used as route target if none is configured
+func vrfRouteTargets(ic *ImportConfig, rd string, vrf string) []string {
+	if rts, ok := ic.VrfRouteTargets[vrf]; ok && vrf != "" {
+		return rts
+	}
+	if rts, ok := ic.VrfRouteTargets[rd]; ok {
+		return rts
+	}
+
+	return []string{rd}
+}
+
+// well known communities (RFC 1997, RFC 9494) and their route range community types
+var wellKnownCommunityTypes = map[uint32]gosnappi.BgpCommunityTypeEnum{
+	0xFFFFFF01: gosnappi.BgpCommunityType.NO_EXPORT,
+	0xFFFFFF02: gosnappi.BgpCommunityType.NO_ADVERTISED,
+	0xFFFFFF03: gosnappi.BgpCommunityType.NO_EXPORT_SUBCONFED,
+	0xFFFF0006: gosnappi.BgpCommunityType.LLGR_STALE,
+	0xFFFF0007: gosnappi.BgpCommunityType.NO_LLGR,
+}
+
+// well known community names as printed by routers
+var wellKnownCommunityNames = map[uint32]string{
+	0xFFFFFF01: "no-export",
+	0xFFFFFF02: "no-advertise",
+	0xFFFFFF03: "no-export-subconfed",
+	0xFFFF0006: "llgr-stale",
+	0xFFFF0007: "no-llgr",
+}
+
+// communityValue converts community notation (e.g. 65000:100, no-export) into its 4 byte value
+func communityValue(c string) (uint32, error) {
+	for value, name := range wellKnownCommunityNames {
+		if strings.EqualFold(c, name) {
+			return value, nil
+		}
+	}
+	parts := strings.Split(c, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid community: %q", c)
+	}
+	as, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid community: %q", c)
+	}
+	value, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid community: %q", c)
+	}
+
+	return uint32(as)<<16 | uint32(value), nil
+}
+
+// communityString converts 4 byte community value into its notation
+func communityString(value uint32) string {
+	if name, ok := wellKnownCommunityNames[value]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("%d:%d", value>>16, value&0xFFFF)
+}
+
+// routeTargetValue converts route target / route distinguisher notation
+// (e.g. 65000:1, 10.0.0.1:1, 4200000000:1) into extended community type
+// and 6 byte hex value
+func routeTargetValue(rt string) (gosnappi.BgpExtCommunityTypeEnum, string, error) {
+	var rtType gosnappi.BgpExtCommunityTypeEnum
+	splits := strings.Split(rt, ":")
+	if len(splits) != 2 {
+		return rtType, "", fmt.Errorf("invalid route target: %q", rt)
+	}
+	value := make([]byte, 6)
+	if ip := net.ParseIP(splits[0]); ip != nil && ip.To4() != nil {
+		num, err := strconv.ParseUint(splits[1], 10, 16)
+		if err != nil {
+			return rtType, "", fmt.Errorf("invalid route target: %q - %v", rt, err)
+		}
+		copy(value, ip.To4())
+		value[4], value[5] = byte(num>>8), byte(num)
+		return gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS, hex.EncodeToString(value), nil
+	}
+
+	admin, err := strconv.ParseUint(splits[0], 10, 32)
+	if err != nil {
+		return rtType, "", fmt.Errorf("invalid route target: %q - %v", rt, err)
+	}
+	if admin <= 0xFFFF {
+		num, err := strconv.ParseUint(splits[1], 10, 32)
+		if err != nil {
+			return rtType, "", fmt.Errorf("invalid route target: %q - %v", rt, err)
+		}
+		value[0], value[1] = byte(admin>>8), byte(admin)
+		value[2], value[3], value[4], value[5] = byte(num>>24), byte(num>>16), byte(num>>8), byte(num)
+		return gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_2OCTET, hex.EncodeToString(value), nil
+	}
+
+	num, err := strconv.ParseUint(splits[1], 10, 16)
+	if err != nil {
+		return rtType, "", fmt.Errorf("invalid route target: %q - %v", rt, err)
+	}
+	value[0], value[1], value[2], value[3] = byte(admin>>24), byte(admin>>16), byte(admin>>8), byte(admin)
+	value[4], value[5] = byte(num>>8), byte(num)
+	return gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET, hex.EncodeToString(value), nil
+}
+
+// RoutesFromConfig returns routes of all bgp peers of the config
+func RoutesFromConfig(config gosnappi.Config) (*[]Route, error) {
+	routes := []Route{}
+	for _, device := range config.Devices().Items() {
+		if !device.HasBgp() {
+			continue
+		}
+		for _, intf := range device.Bgp().Ipv4Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				peerRoutes, err := RoutesFromV4Peer(peer)
+				if err != nil {
+					return nil, err
+				}
+				routes = append(routes, *peerRoutes...)
+			}
+		}
+		for _, intf := range device.Bgp().Ipv6Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				peerRoutes, err := RoutesFromV6Peer(peer)
+				if err != nil {
+					return nil, err
+				}
+				routes = append(routes, *peerRoutes...)
+			}
+		}
+	}
+
+	return &routes, nil
+}
+
+// RoutesFromV4Peer returns routes configured as v4 / v6 route ranges of the bgp v4 peer
+func RoutesFromV4Peer(peer gosnappi.BgpV4Peer) (*[]Route, error) {
+	routes := []Route{}
+	for _, rr := range peer.V4Routes().Items() {
+		rrRoutes, err := routesFromV4RouteRange(rr)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, rrRoutes...)
+	}
+	for _, rr := range peer.V6Routes().Items() {
+		rrRoutes, err := routesFromV6RouteRange(rr)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, rrRoutes...)
+	}
+	for i := range routes {
+		routes[i].Internal = peer.AsType() == gosnappi.BgpV4PeerAsType.IBGP
+	}
+
+	return &routes, nil
+}
+
+// RoutesFromV6Peer returns routes configured as v4 / v6 route ranges of the bgp v6 peer
+func RoutesFromV6Peer(peer gosnappi.BgpV6Peer) (*[]Route, error) {
+	routes := []Route{}
+	for _, rr := range peer.V4Routes().Items() {
+		rrRoutes, err := routesFromV4RouteRange(rr)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, rrRoutes...)
+	}
+	for _, rr := range peer.V6Routes().Items() {
+		rrRoutes, err := routesFromV6RouteRange(rr)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, rrRoutes...)
+	}
+
+	for i := range routes {
+		routes[i].Internal = peer.AsType() == gosnappi.BgpV6PeerAsType.IBGP
+	}
+
+	return &routes, nil
+}
+
+func routesFromV4RouteRange(rr gosnappi.BgpV4RouteRange) ([]Route, error) {
+	template := Route{Name: rr.Name(), Best: true, NextHop: "0.0.0.0"}
+	if rr.NextHopMode() == gosnappi.BgpV4RouteRangeNextHopMode.MANUAL {
+		template.NextHop = rr.NextHopIpv4Address()
+		if rr.NextHopAddressType() == gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6 {
+			template.NextHop = rr.NextHopIpv6Address()
+		}
+	}
+	var asPath gosnappi.BgpAsPath
+	if rr.HasAsPath() {
+		asPath = rr.AsPath()
+	}
+	var advanced gosnappi.BgpRouteAdvanced
+	if rr.HasAdvanced() {
+		advanced = rr.Advanced()
+	}
+	if err := setRouteAttributes(&template, advanced, asPath, rr.Communities().Items(), rr.ExtCommunities().Items()); err != nil {
+		return nil, fmt.Errorf("route range %s: %v", rr.Name(), err)
+	}
+	if rr.HasAddPath() {
+		template.PathId = rr.AddPath().PathId()
+	}
+
+	routes := []Route{}
+	for _, addr := range rr.Addresses().Items() {
+		networks, err := expandNetworks(addr.Address(), addr.Prefix(), addr.Count(), addr.Step())
+		if err != nil {
+			return nil, fmt.Errorf("route range %s: %v", rr.Name(), err)
+		}
+		for _, network := range networks {
+			route := template
+			route.Network, route.PrefixLen = network, int(addr.Prefix())
+			routes = append(routes, route)
+		}
+	}
+
+	return routes, nil
+}
+
+func routesFromV6RouteRange(rr gosnappi.BgpV6RouteRange) ([]Route, error) {
+	template := Route{Name: rr.Name(), Best: true, NextHop: "::"}
+	if rr.NextHopMode() == gosnappi.BgpV6RouteRangeNextHopMode.MANUAL {
+		template.NextHop = rr.NextHopIpv6Address()
+		if rr.NextHopAddressType() == gosnappi.BgpV6RouteRangeNextHopAddressType.IPV4 {
+			template.NextHop = rr.NextHopIpv4Address()
+		}
+	}
+	var asPath gosnappi.BgpAsPath
+	if rr.HasAsPath() {
+		asPath = rr.AsPath()
+	}
+	var advanced gosnappi.BgpRouteAdvanced
+	if rr.HasAdvanced() {
+		advanced = rr.Advanced()
+	}
+	if err := setRouteAttributes(&template, advanced, asPath, rr.Communities().Items(), rr.ExtCommunities().Items()); err != nil {
+		return nil, fmt.Errorf("route range %s: %v", rr.Name(), err)
+	}
+	if rr.HasAddPath() {
+		template.PathId = rr.AddPath().PathId()
+	}
+
+	routes := []Route{}
+	for _, addr := range rr.Addresses().Items() {
+		networks, err := expandNetworks(addr.Address(), addr.Prefix(), addr.Count(), addr.Step())
+		if err != nil {
+			return nil, fmt.Errorf("route range %s: %v", rr.Name(), err)
+		}
+		for _, network := range networks {
+			route := template
+			route.Network, route.PrefixLen = network, int(addr.Prefix())
+			routes = append(routes, route)
+		}
+	}
+
+	return routes, nil
+}
+
+// setRouteAttributes sets path attributes of the route from route range attributes
+func setRouteAttributes(route *Route, advanced gosnappi.BgpRouteAdvanced, asPath gosnappi.BgpAsPath,
+	communities []gosnappi.BgpCommunity, extCommunities []gosnappi.BgpExtCommunity) error {
+	if advanced != nil {
+		if advanced.IncludeMultiExitDiscriminator() && advanced.HasMultiExitDiscriminator() {
+			med := advanced.MultiExitDiscriminator()
+			route.Metric = &med
+		}
+		if advanced.IncludeLocalPreference() && advanced.HasLocalPreference() {
+			locPrf := advanced.LocalPreference()
+			route.LocalPref = &locPrf
+		}
+		if advanced.IncludeOrigin() {
+			route.Origin = advanced.Origin()
+		}
+	}
+	if asPath != nil {
+		for _, seg := range asPath.Segments().Items() {
+			route.AsPath = append(route.AsPath, AsPathSegment{Type: seg.Type(), AsNumbers: seg.AsNumbers()})
+		}
+	}
+	for _, c := range communities {
+		value := c.AsNumber()<<16 | c.AsCustom()&0xFFFF
+		for wellKnown, cType := range wellKnownCommunityTypes {
+			if c.Type() == cType {
+				value = wellKnown
+			}
+		}
+		route.Communities = append(route.Communities, communityString(value))
+	}
+	for _, ec := range extCommunities {
+		if ec.Subtype() != gosnappi.BgpExtCommunitySubtype.ROUTE_TARGET {
+			continue
+		}
+		rt, err := routeTargetString(ec.Type(), ec.Value())
+		if err != nil {
+			return err
+		}
+		route.RouteTargets = append(route.RouteTargets, rt)
+	}
+
+	return nil
+}
+
+// expandNetworks returns count networks starting from address, each step prefix blocks apart
+func expandNetworks(address string, prefix uint32, count uint32, step uint32) ([]net.IP, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("not valid ip address : %q", address)
+	}
+	bits := uint32(128)
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 32
+	}
+	if prefix > bits {
+		return nil, fmt.Errorf("invalid prefix length %d for %q", prefix, address)
+	}
+	increment := new(big.Int).Lsh(big.NewInt(int64(step)), uint(bits-prefix))
+	value := new(big.Int).SetBytes(ip)
+	networks := []net.IP{}
+	for i := uint32(0); i < count; i++ {
+		network := make(net.IP, len(ip))
+		value.FillBytes(network)
+		networks = append(networks, network)
+		value.Add(value, increment)
+		if value.BitLen() > int(bits) {
+			break
+		}
+	}
+
+	return networks, nil
+}
+
+// routeTargetString converts route target extended community value back into
+// route target notation (e.g. 65000:1)
+func routeTargetString(rtType gosnappi.BgpExtCommunityTypeEnum, value string) (string, error) {
+	b, err := hex.DecodeString(value)
+	if err != nil || len(b) != 6 {
+		return "", fmt.Errorf("invalid route target value: %q", value)
+	}
+	switch rtType {
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_IPV4_ADDRESS:
+		return fmt.Sprintf("%s:%d", net.IP(b[:4]).String(), uint32(b[4])<<8|uint32(b[5])), nil
+	case gosnappi.BgpExtCommunityType.ADMINISTRATOR_AS_4OCTET:
+		return fmt.Sprintf("%d:%d", uint32(b[0])<<24|uint32(b[1])<<16|uint32(b[2])<<8|uint32(b[3]),
+			uint32(b[4])<<8|uint32(b[5])), nil
+	}
+
+	return fmt.Sprintf("%d:%d", uint32(b[0])<<8|uint32(b[1]),
+		uint32(b[2])<<24|uint32(b[3])<<16|uint32(b[4])<<8|uint32(b[5])), nil
+}
@@ -0,0 +1,230 @@
+package routeimporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	ROA_CSV_HEADER_ASN        = "ASN"
+	ROA_CSV_HEADER_PREFIX     = "IP Prefix"
+	ROA_CSV_HEADER_MAX_LENGTH = "Max Length"
+)
+
+// roaJson is a ROA of rpki-client / Routinator JSON export, asn is a number or "AS<number>"
+type roaJson struct {
+	Asn       json.RawMessage `json:"asn"`
+	Prefix    string          `json:"prefix"`
+	MaxLength int             `json:"maxLength"`
+}
+
+// roaTable indexes ROAs per address family, prefix length and prefix
+type roaTable struct {
+	v4 map[int]map[string][]Roa
+	v6 map[int]map[string][]Roa
+
+	// origin AS of locally originated routes, 0 if not known
+	localAs uint32
+}
+
+// String returns the name of the RPKI state
+func (state RpkiState) String() string {
+	switch state {
+	case RpkiStateValid:
+		return "valid"
+	case RpkiStateInvalid:
+		return "invalid"
+	case RpkiStateNotFound:
+		return "not-found"
+	}
+
+	return "unknown"
+}
+
+// LoadRoas parses ROAs of a local RPKI validator export, either rpki-client /
+// Routinator JSON ({"roas": [...]}) or CSV (ASN,IP Prefix,Max Length,... header)
+func LoadRoas(buffer *[]byte) ([]Roa, error) {
+	if buffer == nil || len(bytes.TrimSpace(*buffer)) == 0 {
+		return nil, fmt.Errorf("cannot load ROAs - empty buffer")
+	}
+	if bytes.TrimSpace(*buffer)[0] == '{' {
+		return loadRoasJson(*buffer)
+	}
+
+	return loadRoasCsv(*buffer)
+}
+
+func loadRoasJson(buffer []byte) ([]Roa, error) {
+	export := struct {
+		Roas []roaJson `json:"roas"`
+	}{}
+	if err := json.Unmarshal(buffer, &export); err != nil {
+		return nil, fmt.Errorf("invalid ROA JSON: %v", err)
+	}
+	roas := []Roa{}
+	for i, r := range export.Roas {
+		asn := strings.Trim(string(r.Asn), `"`)
+		roa, err := newRoa(asn, r.Prefix, r.MaxLength)
+		if err != nil {
+			return nil, fmt.Errorf("%v (roa %d)", err, i+1)
+		}
+		roas = append(roas, roa)
+	}
+
+	return roas, nil
+}
+
+func loadRoasCsv(buffer []byte) ([]Roa, error) {
+	reader := csv.NewReader(bytes.NewReader(buffer))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ROA CSV: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid ROA CSV: header not found")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{ROA_CSV_HEADER_ASN, ROA_CSV_HEADER_PREFIX, ROA_CSV_HEADER_MAX_LENGTH} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("invalid ROA CSV: column %q not found", name)
+		}
+	}
+
+	roas := []Roa{}
+	for row, record := range records[1:] {
+		if len(record) <= columns[ROA_CSV_HEADER_ASN] || len(record) <= columns[ROA_CSV_HEADER_PREFIX] ||
+			len(record) <= columns[ROA_CSV_HEADER_MAX_LENGTH] {
+			return nil, fmt.Errorf("missing ROA columns (line %d)", row+2)
+		}
+		maxLength := 0
+		if value := strings.TrimSpace(record[columns[ROA_CSV_HEADER_MAX_LENGTH]]); value != "" {
+			if maxLength, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("invalid max length %q (line %d)", value, row+2)
+			}
+		}
+		roa, err := newRoa(record[columns[ROA_CSV_HEADER_ASN]], record[columns[ROA_CSV_HEADER_PREFIX]], maxLength)
+		if err != nil {
+			return nil, fmt.Errorf("%v (line %d)", err, row+2)
+		}
+		roas = append(roas, roa)
+	}
+
+	return roas, nil
+}
+
+// newRoa creates a ROA, max length defaults to the prefix length
+func newRoa(asn string, prefix string, maxLength int) (Roa, error) {
+	asn = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(asn)), "AS")
+	num, err := parseAsNumber(asn)
+	if err != nil {
+		return Roa{}, fmt.Errorf("invalid ROA ASN %q", asn)
+	}
+	_, network, err := net.ParseCIDR(strings.TrimSpace(prefix))
+	if err != nil {
+		return Roa{}, fmt.Errorf("invalid ROA prefix %q", prefix)
+	}
+	length, bits := network.Mask.Size()
+	if maxLength == 0 {
+		maxLength = length
+	}
+	if maxLength < length || maxLength > bits {
+		return Roa{}, fmt.Errorf("invalid ROA max length %d for %s", maxLength, prefix)
+	}
+
+	return Roa{Prefix: *network, MaxLength: maxLength, Asn: uint32(num)}, nil
+}
+
+func newRoaTable(roas []Roa) *roaTable {
+	table := &roaTable{v4: map[int]map[string][]Roa{}, v6: map[int]map[string][]Roa{}}
+	for _, roa := range roas {
+		index := table.v6
+		if roa.Prefix.IP.To4() != nil {
+			index = table.v4
+		}
+		length, _ := roa.Prefix.Mask.Size()
+		if index[length] == nil {
+			index[length] = map[string][]Roa{}
+		}
+		key := roa.Prefix.IP.String()
+		index[length][key] = append(index[length][key], roa)
+	}
+
+	return table
+}
+
+// validate returns RPKI origin validation state of the route as per RFC 6811
+func (table *roaTable) validate(route *Route) RpkiState {
+	if route.Network == nil {
+		return RpkiStateUnknown
+	}
+	index, bits := table.v6, 128
+	if route.Network.To4() != nil {
+		index, bits = table.v4, 32
+	}
+	origin, asSet, ok := originAs(route)
+	hasOrigin := ok && !asSet
+	if !ok {
+		// locally originated, no origin AS to validate without local AS
+		if table.localAs == 0 {
+			return RpkiStateNotFound
+		}
+		origin, hasOrigin = table.localAs, true
+	}
+
+	covered := false
+	for length := 0; length <= route.PrefixLen; length++ {
+		if index[length] == nil {
+			continue
+		}
+		key := route.Network.Mask(net.CIDRMask(length, bits)).String()
+		for _, roa := range index[length][key] {
+			covered = true
+			if hasOrigin && roa.Asn == origin && route.PrefixLen <= roa.MaxLength {
+				return RpkiStateValid
+			}
+		}
+	}
+	if covered {
+		return RpkiStateInvalid
+	}
+
+	return RpkiStateNotFound
+}
+
+// rpkiLocalAs returns the AS originating routes without AS path, the AS of the first
+// target peer unless set in the import config
+func rpkiLocalAs(ic *ImportConfig) uint32 {
+	switch {
+	case ic.RpkiLocalAs != 0:
+		return ic.RpkiLocalAs
+	case len(ic.Targetv4Peers) > 0:
+		return ic.Targetv4Peers[0].AsNumber()
+	case len(ic.Targetv6Peers) > 0:
+		return ic.Targetv6Peers[0].AsNumber()
+	}
+
+	return 0
+}
+
+// isSelectedRpkiState checks if routes of the RPKI state are to be imported
+func isSelectedRpkiState(ic *ImportConfig, state RpkiState) bool {
+	if len(ic.RpkiStates) == 0 {
+		return true
+	}
+	for _, s := range ic.RpkiStates {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
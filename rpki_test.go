@@ -0,0 +1,168 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestLoadRoas(t *testing.T) {
+	for _, filename := range []string{"resource/roas.json", "resource/roas.csv"} {
+		fb, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not Read ROA file: %s. Error: %v", filename, err))
+			return
+		}
+		roas, err := routeimporter.LoadRoas(&fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not load ROAs of %s. error: %v", filename, err))
+			continue
+		}
+		if len(roas) != 3 {
+			t.Errorf("Expected 3 ROAs in %s, found %d", filename, len(roas))
+			continue
+		}
+		if roas[1].Asn != 13335 || roas[1].Prefix.String() != "1.1.1.0/24" || roas[1].MaxLength != 24 {
+			t.Errorf("Unexpected ROA %+v in %s", roas[1], filename)
+		}
+		if roas[2].Prefix.String() != "2001:4860::/32" || roas[2].MaxLength != 48 {
+			t.Errorf("Unexpected ROA %+v in %s", roas[2], filename)
+		}
+	}
+}
+
+func TestImportRoutesRpki(t *testing.T) {
+	rb, err := os.ReadFile("resource/roas.csv")
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read ROA file. Error: %v", err))
+		return
+	}
+	roas, err := routeimporter.LoadRoas(&rb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not load ROAs. error: %v", err))
+		return
+	}
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "rpki",
+		RRType:     routeimporter.RouteTypeIpv4,
+		Roas:       roas,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	// fourth path of 1.0.0.0/24 originates from AS56203
+	expStates := []routeimporter.RpkiState{
+		routeimporter.RpkiStateValid, routeimporter.RpkiStateValid, routeimporter.RpkiStateValid,
+		routeimporter.RpkiStateInvalid, routeimporter.RpkiStateNotFound, routeimporter.RpkiStateNotFound,
+	}
+	states := []routeimporter.RpkiState{}
+	for _, route := range *routes {
+		states = append(states, route.RpkiState)
+	}
+	if !reflect.DeepEqual(states, expStates) {
+		t.Errorf("Expected RPKI states %v, found %v", expStates, states)
+	}
+
+	ic.RpkiStates = []routeimporter.RpkiState{routeimporter.RpkiStateValid}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 3 {
+		t.Errorf("Expected 3 valid routes, found %d", len(*routes))
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+	ic.RpkiStates = nil
+	ic.RouteGroups = routeimporter.RouteGroupRpki
+	ic.Targetv4Peers = []gosnappi.BgpV4Peer{peer}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	groups := map[string]int{}
+	for _, group := range peer.V4RouteGroups().Items() {
+		groups[group.Name()] = len(group.RouteNames())
+	}
+	expGroups := map[string]int{"rpki-rpki-valid": 3, "rpki-rpki-invalid": 1, "rpki-rpki-not-found": 2}
+	if !reflect.DeepEqual(groups, expGroups) {
+		t.Errorf("Expected route groups %v, found %v", expGroups, groups)
+	}
+}
+
+func TestParseRoutesRpkiLocalOrigin(t *testing.T) {
+	rb, err := os.ReadFile("resource/roas.csv")
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read ROA file. Error: %v", err))
+		return
+	}
+	roas, err := routeimporter.LoadRoas(&rb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not load ROAs. error: %v", err))
+		return
+	}
+	// locally originated route, no AS path
+	fb := []byte("routes:\n  - prefix: 1.1.1.0/24\n    next_hop: 192.0.2.1\n")
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeRouteList)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	tests := []struct {
+		LocalAs uint32
+		State   routeimporter.RpkiState
+	}{
+		{0, routeimporter.RpkiStateNotFound},
+		{13335, routeimporter.RpkiStateValid},
+		{65001, routeimporter.RpkiStateInvalid},
+	}
+	for _, test := range tests {
+		ic := routeimporter.ImportConfig{NamePrefix: "rpki", Roas: roas, RpkiLocalAs: test.LocalAs}
+		routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+			return
+		}
+		if state := (*routes)[0].RpkiState; state != test.State {
+			t.Errorf("Local AS %d: expected RPKI state %s, found %s", test.LocalAs, test.State, state)
+		}
+	}
+
+	// local AS of the target peer
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(13335)
+	ic := routeimporter.ImportConfig{NamePrefix: "rpki", Roas: roas, Targetv4Peers: []gosnappi.BgpV4Peer{peer}}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if state := (*routes)[0].RpkiState; state != routeimporter.RpkiStateValid {
+		t.Errorf("Expected RPKI state valid with target peer AS, found %s", state)
+	}
+}
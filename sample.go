@@ -0,0 +1,92 @@
+package routeimporter
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// sampleRoutes returns the subset of routes selected by the sample mode of the import config,
+// in import order
+func sampleRoutes(ic *ImportConfig, routes []Route) ([]Route, error) {
+	if ic.Sample == SampleNone {
+		return routes, nil
+	}
+	if ic.SampleSize <= 0 {
+		return nil, fmt.Errorf("invalid sample size %d", ic.SampleSize)
+	}
+	if ic.SampleSize >= len(routes) {
+		return routes, nil
+	}
+
+	rng := rand.New(rand.NewSource(ic.SampleSeed))
+	indexes := []int{}
+	switch ic.Sample {
+	case SampleEveryNth:
+		for i := 0; i < ic.SampleSize; i++ {
+			indexes = append(indexes, i*len(routes)/ic.SampleSize)
+		}
+	case SampleRandom:
+		indexes = rng.Perm(len(routes))[:ic.SampleSize]
+	case SamplePrefixLen, SampleOriginAs:
+		strata := map[string][]int{}
+		for i := range routes {
+			key := fmt.Sprint(routes[i].PrefixLen)
+			if ic.Sample == SampleOriginAs {
+				key = "local"
+				if as, _, ok := originAs(&routes[i]); ok {
+					key = fmt.Sprint(as)
+				}
+			}
+			strata[key] = append(strata[key], i)
+		}
+		keys := []string{}
+		for key := range strata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		quotas := sampleQuotas(keys, strata, len(routes), ic.SampleSize)
+		for _, key := range keys {
+			stratum := strata[key]
+			for _, i := range rng.Perm(len(stratum))[:quotas[key]] {
+				indexes = append(indexes, stratum[i])
+			}
+		}
+	case SampleFirst:
+		for i := 0; i < ic.SampleSize; i++ {
+			indexes = append(indexes, i)
+		}
+	default:
+		return nil, fmt.Errorf("unknown sample mode %d", ic.Sample)
+	}
+
+	sort.Ints(indexes)
+	sampled := make([]Route, 0, len(indexes))
+	for _, i := range indexes {
+		sampled = append(sampled, routes[i])
+	}
+
+	return sampled, nil
+}
+
+// sampleQuotas splits size among the strata in proportion to their routes,
+// remainders going to the strata with largest fractions
+func sampleQuotas(keys []string, strata map[string][]int, total int, size int) map[string]int {
+	quotas := map[string]int{}
+	remainders := map[string]int{}
+	assigned := 0
+	for _, key := range keys {
+		share := len(strata[key]) * size
+		quotas[key], remainders[key] = share/total, share%total
+		assigned += quotas[key]
+	}
+	byRemainder := append([]string{}, keys...)
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return remainders[byRemainder[i]] > remainders[byRemainder[j]]
+	})
+	for _, key := range byRemainder[:size-assigned] {
+		quotas[key]++
+	}
+
+	return quotas
+}
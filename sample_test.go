@@ -0,0 +1,85 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+)
+
+func TestParseRoutesSample(t *testing.T) {
+	filename := "resource/cisco_v4_1K.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	all, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "s"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	allStats, _ := routeimporter.Analyze(all, 3)
+
+	for _, mode := range []routeimporter.SampleMode{
+		routeimporter.SampleEveryNth, routeimporter.SampleRandom, routeimporter.SamplePrefixLen,
+		routeimporter.SampleOriginAs, routeimporter.SampleFirst,
+	} {
+		ic := routeimporter.ImportConfig{NamePrefix: "s", Sample: mode, SampleSize: 100, SampleSeed: 5}
+		routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes of sample mode %d. error: %v", mode, err))
+			continue
+		}
+		if len(*routes) != 100 {
+			t.Errorf("Sample mode %d: expected 100 routes, found %d", mode, len(*routes))
+			continue
+		}
+		for i := 1; i < len(*routes); i++ {
+			if (*routes)[i].Row <= (*routes)[i-1].Row {
+				t.Errorf("Sample mode %d: routes not in import order", mode)
+				break
+			}
+		}
+		again, err := routeimporter.ParseRoutes(is, ic, &fb)
+		if err != nil || !reflect.DeepEqual(*again, *routes) {
+			t.Errorf("Sample mode %d: expected same routes across runs, error: %v", mode, err)
+		}
+
+		stats, _ := routeimporter.Analyze(routes, 3)
+		switch mode {
+		case routeimporter.SampleEveryNth:
+			if (*routes)[0].Name != (*all)[0].Name || (*routes)[1].Name != (*all)[10].Name {
+				t.Errorf("Sample mode %d: expected every 10th route, found %s, %s", mode, (*routes)[0].Name, (*routes)[1].Name)
+			}
+		case routeimporter.SamplePrefixLen:
+			for length, count := range allStats.Ipv4PrefixLengths {
+				if diff := stats.Ipv4PrefixLengths[length] - count/10; diff < 0 || diff > 1 {
+					t.Errorf("Sample mode %d: expected %d /%d routes, found %d", mode, count/10, length,
+						stats.Ipv4PrefixLengths[length])
+				}
+			}
+		case routeimporter.SampleOriginAs:
+			expected := []routeimporter.AsCount{{As: 23969, Routes: 66}, {As: 9583, Routes: 21}, {As: 2519, Routes: 10}}
+			if !reflect.DeepEqual(stats.TopOriginAs, expected) {
+				t.Errorf("Sample mode %d: expected origin ASes %v, found %v", mode, expected, stats.TopOriginAs)
+			}
+		case routeimporter.SampleFirst:
+			if !reflect.DeepEqual(*routes, (*all)[:100]) {
+				t.Errorf("Sample mode %d: expected first 100 routes", mode)
+			}
+		}
+	}
+
+	ic := routeimporter.ImportConfig{NamePrefix: "s", Sample: routeimporter.SampleRandom}
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for missing sample size")
+	}
+}
@@ -0,0 +1,166 @@
+package routeimporter
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// scaleReserved are the networks scale copies must not land in, 0/8, multicast and class E
+var scaleReserved = []string{"0.0.0.0/8", "224.0.0.0/4", "240.0.0.0/4", "ff00::/8"}
+
+// scaleRoutes returns the routes followed by their copies in additional address space,
+// and the count of copies skipped as their prefix is already in the table
+func scaleRoutes(ic *ImportConfig, routes []Route) ([]Route, int, error) {
+	if ic.Scale == nil || ic.Scale.Copies == 0 {
+		return routes, 0, nil
+	}
+	if ic.Scale.Copies < 0 {
+		return nil, 0, fmt.Errorf("invalid scale copies %d", ic.Scale.Copies)
+	}
+	v4Offset, err := scaleOffset(ic.Scale.Ipv4Offset, routes, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	v6Offset, err := scaleOffset(ic.Scale.Ipv6Offset, routes, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	reserved := []*net.IPNet{}
+	for _, cidr := range scaleReserved {
+		_, network, _ := net.ParseCIDR(cidr)
+		reserved = append(reserved, network)
+	}
+
+	// prefixes of the table and the route they are copied from, paths of a prefix are copied together
+	sources := map[string]string{}
+	for i := range routes {
+		key := routePrefixKey(&routes[i])
+		sources[key] = key
+	}
+	scaled := make([]Route, 0, len(routes)*(ic.Scale.Copies+1))
+	scaled = append(scaled, routes...)
+	skipped := 0
+	for n := 1; n <= ic.Scale.Copies; n++ {
+		for _, route := range routes {
+			if route.Network == nil || route.Evpn != nil {
+				continue
+			}
+			source := fmt.Sprintf("%s|%d", routePrefixKey(&route), n)
+			network, offset, bits := route.Network.To4(), v4Offset, 32
+			if network == nil {
+				network, offset, bits = route.Network, v6Offset, 128
+			}
+			value := new(big.Int).Mul(offset, big.NewInt(int64(n)))
+			value.Add(value, new(big.Int).SetBytes(network))
+			if value.BitLen() > bits {
+				return nil, 0, fmt.Errorf("scale copy %d of %s/%d wraps around the address space",
+					n, route.Network, route.PrefixLen)
+			}
+			route.Network = addAddress(network, offset, n).Mask(net.CIDRMask(route.PrefixLen, bits))
+			route.Copy = n
+			for _, r := range reserved {
+				copied := &net.IPNet{IP: route.Network, Mask: net.CIDRMask(route.PrefixLen, bits)}
+				if r.Contains(copied.IP) || copied.Contains(r.IP) {
+					return nil, 0, fmt.Errorf("scale copy %d %s/%d lands in reserved network %s",
+						n, route.Network, route.PrefixLen, r)
+				}
+			}
+			if ic.Scale.OriginAsOffset != 0 {
+				route.AsPath = offsetOriginAs(route.AsPath, ic.Scale.OriginAsOffset*uint32(n))
+			}
+			if nextHop := net.ParseIP(route.NextHop); ic.Scale.VaryNextHop && nextHop != nil {
+				if nextHop.To4() != nil {
+					nextHop = nextHop.To4()
+				}
+				route.NextHop = addAddress(nextHop, big.NewInt(1), n).String()
+			}
+			key := routePrefixKey(&route)
+			if owner, ok := sources[key]; ok && owner != source {
+				skipped++
+				continue
+			}
+			sources[key] = source
+			scaled = append(scaled, route)
+		}
+	}
+
+	return scaled, skipped, nil
+}
+
+// scaleOffset returns the scale offset address as number. If empty, the offset is the smallest
+// power of two spanning the networks of the family in the table, so that each copy lands in a
+// block of its own past the table.
+func scaleOffset(offset string, routes []Route, v6 bool) (*big.Int, error) {
+	if offset != "" {
+		ip := net.ParseIP(offset)
+		if ip == nil || (ip.To4() == nil) != v6 || ip.IsUnspecified() {
+			return nil, fmt.Errorf("invalid scale offset %q", offset)
+		}
+		if !v6 {
+			ip = ip.To4()
+		}
+		return new(big.Int).SetBytes(ip), nil
+	}
+
+	var low, high *big.Int
+	for i := range routes {
+		route := &routes[i]
+		if route.Network == nil || route.Evpn != nil || (route.Network.To4() == nil) != v6 {
+			continue
+		}
+		network, bits := route.Network.To4(), 32
+		if v6 {
+			network, bits = route.Network.To16(), 128
+		}
+		first := new(big.Int).SetBytes(network.Mask(net.CIDRMask(route.PrefixLen, bits)))
+		end := new(big.Int).Add(first, new(big.Int).Lsh(big.NewInt(1), uint(bits-route.PrefixLen)))
+		if low == nil || first.Cmp(low) < 0 {
+			low = first
+		}
+		if high == nil || end.Cmp(high) > 0 {
+			high = end
+		}
+	}
+	block := big.NewInt(1)
+	if low == nil {
+		return block, nil
+	}
+	for span := new(big.Int).Sub(high, low); block.Cmp(span) < 0; {
+		block.Lsh(block, 1)
+	}
+
+	return block, nil
+}
+
+// addAddress returns the address incremented times by offset, wrapping around the address space
+func addAddress(ip net.IP, offset *big.Int, times int) net.IP {
+	bits := uint(len(ip) * 8)
+	value := new(big.Int).Mul(offset, big.NewInt(int64(times)))
+	value.Add(value, new(big.Int).SetBytes(ip))
+	value.Mod(value, new(big.Int).Lsh(big.NewInt(1), bits))
+	address := make(net.IP, len(ip))
+	value.FillBytes(address)
+
+	return address
+}
+
+// offsetOriginAs returns a copy of the AS path with offset added to the origin AS,
+// paths ending with AS_SET are returned as is
+func offsetOriginAs(segments []AsPathSegment, offset uint32) []AsPathSegment {
+	if len(segments) == 0 {
+		return segments
+	}
+	last := segments[len(segments)-1]
+	if last.Type != gosnappi.BgpAsPathSegmentType.AS_SEQ || len(last.AsNumbers) == 0 {
+		return segments
+	}
+	path := append([]AsPathSegment{}, segments...)
+	asNumbers := append([]uint32{}, last.AsNumbers...)
+	asNumbers[len(asNumbers)-1] += offset
+	path[len(path)-1] = AsPathSegment{Type: last.Type, AsNumbers: asNumbers}
+
+	return path
+}
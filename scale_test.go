@@ -0,0 +1,146 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesScale(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "sc",
+		RRType:        routeimporter.RouteTypeIpv4,
+		RetainNexthop: true,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		Scale: &routeimporter.ScaleConfig{
+			Copies:         2,
+			Ipv4Offset:     "10.0.0.0",
+			OriginAsOffset: 1000,
+			VaryNextHop:    true,
+		},
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 18 {
+		t.Errorf("Expected 18 routes, found %d", len(*routes))
+		return
+	}
+	original, first, second := (*routes)[3], (*routes)[9], (*routes)[15]
+	if first.Name != "sc-11-c1" || second.Name != "sc-11-c2" {
+		t.Errorf("Unexpected copy names %s, %s", first.Name, second.Name)
+	}
+	if first.Network.String() != "11.0.0.0" || second.Network.String() != "21.0.0.0" || first.PrefixLen != 24 {
+		t.Errorf("Unexpected copy networks %s, %s", first.Network, second.Network)
+	}
+	if first.NextHop != "67.16.148.38" || second.NextHop != "67.16.148.39" {
+		t.Errorf("Unexpected copy next hops %s, %s", first.NextHop, second.NextHop)
+	}
+	if !reflect.DeepEqual(second.AsPath[0].AsNumbers, []uint32{6939, 6939, 7545, 58203}) ||
+		!reflect.DeepEqual(original.AsPath[0].AsNumbers, []uint32{6939, 6939, 7545, 56203}) {
+		t.Errorf("Unexpected AS paths %v, %v", original.AsPath, second.AsPath)
+	}
+	if *first.Metric != *original.Metric || *first.LocalPref != *original.LocalPref || first.Origin != original.Origin {
+		t.Errorf("Expected copy attributes as original, found %+v", first)
+	}
+
+	names, err := is.ImportRoutes(ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+	if len(*names) != 18 || len(peer.V4Routes().Items()) != 18 {
+		t.Errorf("Expected 18 route ranges, found %d", len(peer.V4Routes().Items()))
+	}
+}
+
+func TestImportRoutesScaleOverlap(t *testing.T) {
+	filename := "resource/cisco_v4_basic.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	// first copy of 1.0.0.0/24 is 1.0.5.0/24, already in table
+	report := routeimporter.ImportReport{}
+	ic := routeimporter.ImportConfig{
+		NamePrefix: "sc",
+		Scale:      &routeimporter.ScaleConfig{Copies: 1, Ipv4Offset: "0.0.5.0"},
+		Report:     &report,
+	}
+	routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 8 || (*routes)[6].Network.String() != "1.0.10.0" {
+		t.Errorf("Expected copies of 1.0.5.0/24 only, found %d routes", len(*routes))
+	}
+	if report.ScaleSkipped != 4 {
+		t.Errorf("Expected 4 skipped copies, found %d", report.ScaleSkipped)
+	}
+
+	// fourth copy with offset 64.0.0.0 is back on the table
+	ic.Scale = &routeimporter.ScaleConfig{Copies: 3, Ipv4Offset: "64.0.0.0"}
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+	}
+	ic.Scale.Copies = 4
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for scale offset wrapping around the address space")
+	}
+
+	// second copy with offset 112.0.0.0 is multicast
+	ic.Scale = &routeimporter.ScaleConfig{Copies: 2, Ipv4Offset: "112.0.0.0"}
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for scale copy in multicast address space")
+	}
+
+	// default offset is the block spanning the table, 1.0.0.0 - 1.0.5.255
+	ic.Scale = &routeimporter.ScaleConfig{Copies: 2}
+	routes, err = routeimporter.ParseRoutes(is, ic, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	if len(*routes) != 18 || (*routes)[6].Network.String() != "1.0.8.0" || (*routes)[17].Network.String() != "1.0.21.0" {
+		t.Errorf("Expected copies at 1.0.8.0 and 1.0.16.0, found %d routes", len(*routes))
+	}
+	if report.ScaleSkipped != 0 {
+		t.Errorf("Expected no skipped copies, found %d", report.ScaleSkipped)
+	}
+
+	ic.Scale.Ipv4Offset = "::1"
+	if _, err := routeimporter.ParseRoutes(is, ic, &fb); err == nil {
+		t.Errorf("Expected error for IPv6 offset of IPv4 routes")
+	}
+}
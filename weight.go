@@ -0,0 +1,71 @@
+package routeimporter
+
+import (
+	"math"
+	"sort"
+)
+
+const DEFAULT_LOCAL_PREF = 100
+
+// weightLocalPref raises local pref of paths of prefixes with different weights, so that
+// local pref based best path selection prefers the paths of highest weight as weight does,
+// local pref order being kept among paths of same weight
+func weightLocalPref(routes []Route) {
+	paths := map[string][]int{}
+	keys := []string{}
+	for i := range routes {
+		key := routePrefixKey(&routes[i])
+		if _, ok := paths[key]; !ok {
+			keys = append(keys, key)
+		}
+		paths[key] = append(paths[key], i)
+	}
+
+	for _, key := range keys {
+		weights := []uint32{}
+		found := map[uint32]bool{}
+		span := uint64(0)
+		for _, i := range paths[key] {
+			weight, locPrf := routeWeight(&routes[i]), routeLocalPref(&routes[i])
+			if !found[weight] {
+				found[weight] = true
+				weights = append(weights, weight)
+			}
+			if uint64(locPrf)+1 > span {
+				span = uint64(locPrf) + 1
+			}
+		}
+		if len(weights) < 2 {
+			continue
+		}
+		sort.Slice(weights, func(i, j int) bool { return weights[i] < weights[j] })
+		rank := map[uint32]uint32{}
+		for r, weight := range weights {
+			rank[weight] = uint32(r)
+		}
+		for _, i := range paths[key] {
+			value := uint64(routeLocalPref(&routes[i])) + uint64(rank[routeWeight(&routes[i])])*span
+			locPrf := uint32(math.MaxUint32)
+			if value < math.MaxUint32 {
+				locPrf = uint32(value)
+			}
+			routes[i].LocalPref = &locPrf
+		}
+	}
+}
+
+// routeWeight returns weight of the route, 0 if not present
+func routeWeight(route *Route) uint32 {
+	if route.Weight == nil {
+		return 0
+	}
+	return *route.Weight
+}
+
+// routeLocalPref returns local pref of the route, DEFAULT_LOCAL_PREF if not present
+func routeLocalPref(route *Route) uint32 {
+	if route.LocalPref == nil {
+		return DEFAULT_LOCAL_PREF
+	}
+	return *route.LocalPref
+}
@@ -0,0 +1,116 @@
+package routeimporter_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/routeimporter"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestImportRoutesWeight(t *testing.T) {
+	filename := "resource/cisco_v4_weight.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+
+	tests := []struct {
+		WeightLocalPref bool
+		LocalPrefs      []uint32
+	}{
+		{false, []uint32{200, 200, 200, 300, 300, 400}},
+		{true, []uint32{200, 802, 501, 601, 300, 801}},
+	}
+	for _, test := range tests {
+		ic := routeimporter.ImportConfig{NamePrefix: "w", WeightLocalPref: test.WeightLocalPref}
+		routes, err := routeimporter.ParseRoutes(is, ic, &fb)
+		if err != nil {
+			t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+			return
+		}
+		weights, locPrfs := []uint32{}, []uint32{}
+		for _, route := range *routes {
+			weights = append(weights, *route.Weight)
+			locPrfs = append(locPrfs, *route.LocalPref)
+		}
+		if !reflect.DeepEqual(weights, []uint32{0, 32768, 100, 100, 0, 200}) {
+			t.Errorf("Unexpected weights %v", weights)
+		}
+		if !reflect.DeepEqual(locPrfs, test.LocalPrefs) {
+			t.Errorf("WeightLocalPref %v: expected local prefs %v, found %v", test.WeightLocalPref, test.LocalPrefs, locPrfs)
+		}
+	}
+
+	routes, err := routeimporter.ParseRoutes(is, routeimporter.ImportConfig{NamePrefix: "w"}, &fb)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not parse routes. error: %v", err))
+		return
+	}
+	es, err := routeimporter.GetExporterService(routeimporter.ExportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Exporter Service. Error: %v", err))
+		return
+	}
+	exported, err := es.ExportRoutes(routes)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not export routes. error: %v", err))
+		return
+	}
+	if !strings.Contains(string(*exported), "   200  32768 15169 i") {
+		t.Errorf("Expected weight column in exported table:\n%s", string(*exported))
+	}
+}
+
+func TestImportRoutesWeightRouteGroups(t *testing.T) {
+	filename := "resource/cisco_v4_weight.txt"
+	fb, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not Read import file: %s. Error: %v", filename, err))
+		return
+	}
+
+	config := gosnappi.NewConfig()
+	peer := config.Devices().Add().SetName("devA").Bgp().SetRouterId("1.1.1.1").
+		Ipv4Interfaces().Add().SetIpv4Name("intA").Peers().Add().SetName("peerA")
+	peer.SetPeerAddress("1.1.1.1").SetAsType(gosnappi.BgpV4PeerAsType.IBGP).SetAsNumber(65001)
+
+	ic := routeimporter.ImportConfig{
+		NamePrefix:    "w",
+		RRType:        routeimporter.RouteTypeIpv4,
+		Targetv4Peers: []gosnappi.BgpV4Peer{peer},
+		RouteGroups:   routeimporter.RouteGroupWeight,
+	}
+	is, err := routeimporter.GetImporterService(routeimporter.ImportFileTypeCisco)
+	if err != nil {
+		t.Errorf(fmt.Sprintf("Could not create Route Importer Service. Error: %v", err))
+		return
+	}
+	if _, err := is.ImportRoutes(ic, &fb); err != nil {
+		t.Errorf(fmt.Sprintf("Could not import routes. error: %v", err))
+		return
+	}
+
+	groups := map[string][]string{}
+	for _, group := range peer.V4RouteGroups().Items() {
+		groups[group.Name()] = group.RouteNames()
+	}
+	expected := map[string][]string{
+		"w-weight0":     {"w-8", "w-12"},
+		"w-weight32768": {"w-9"},
+		"w-weight100":   {"w-10", "w-11"},
+		"w-weight200":   {"w-13"},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected route groups %v, found %v", expected, groups)
+	}
+}